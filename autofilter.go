@@ -0,0 +1,205 @@
+package xlsxreader
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// AutoFilter represents the filter applied to a range of cells within a sheet. The first row
+// of the range holds the headers, and the rows beneath which do not meet the criteria of
+// every filtered column are hidden.
+type AutoFilter struct {
+	Ref     string // E.G   A1:D20
+	Columns []FilterColumn
+}
+
+// FilterColumn holds the criteria a column of an AutoFilter is filtered by.
+// Only one kind of criteria is set for each column.
+type FilterColumn struct {
+	Column string // E.G   A, B, C
+
+	// Values lists the values to be shown, with Dates listing any dates, and Blank
+	// indicating that empty cells are also shown.
+	Values []string
+	Dates  []DateGroup
+	Blank  bool
+
+	// CustomFilters compares values against one or two criteria. If MatchAll is set, the
+	// value must meet every criteria, rather than any of them.
+	CustomFilters []CustomFilter
+	MatchAll      bool
+
+	Top10   *Top10Filter
+	Dynamic *DynamicFilter
+}
+
+// DateGroup matches the dates which fall within a year, month, day, hour, minute or second.
+// Grouping gives the most precise part of the date which must match, E.G   month
+type DateGroup struct {
+	Grouping string
+	Year     int
+	Month    int
+	Day      int
+	Hour     int
+	Minute   int
+	Second   int
+}
+
+// CustomFilter compares values against a criteria.
+// Operator is one of equal, lessThan, lessThanOrEqual, notEqual, greaterThanOrEqual or
+// greaterThan. Value may contain the wildcards * and ?.
+type CustomFilter struct {
+	Operator string
+	Value    string
+}
+
+// Top10Filter shows the highest or lowest values, either by number of items or by percent.
+type Top10Filter struct {
+	Top         bool
+	Percent     bool
+	Value       float64 // The number of items, or percentage, to show
+	FilterValue float64 // The value at which the cut-off is made, as last calculated
+}
+
+// DynamicFilter shows values according to a criteria which can change over time, such as
+// aboveAverage, today or Q1. Value and MaxValue hold the bounds the criteria gave when it
+// was last calculated, where applicable.
+type DynamicFilter struct {
+	Type     string
+	Value    float64
+	MaxValue float64
+}
+
+type rawAutoFilter struct {
+	Ref           string            `xml:"ref,attr"`
+	FilterColumns []rawFilterColumn `xml:"filterColumn"`
+}
+
+type rawFilterColumn struct {
+	ColumnID      int               `xml:"colId,attr"`
+	Filters       *rawFilters       `xml:"filters"`
+	CustomFilters *rawCustomFilters `xml:"customFilters"`
+	Top10         *rawTop10         `xml:"top10"`
+	DynamicFilter *rawDynamicFilter `xml:"dynamicFilter"`
+}
+
+type rawFilters struct {
+	Blank   bool `xml:"blank,attr"`
+	Filters []struct {
+		Value string `xml:"val,attr"`
+	} `xml:"filter"`
+	DateGroups []rawDateGroup `xml:"dateGroupItem"`
+}
+
+type rawDateGroup struct {
+	Grouping string `xml:"dateTimeGrouping,attr"`
+	Year     int    `xml:"year,attr"`
+	Month    int    `xml:"month,attr"`
+	Day      int    `xml:"day,attr"`
+	Hour     int    `xml:"hour,attr"`
+	Minute   int    `xml:"minute,attr"`
+	Second   int    `xml:"second,attr"`
+}
+
+type rawCustomFilters struct {
+	And           bool `xml:"and,attr"`
+	CustomFilters []struct {
+		Operator string `xml:"operator,attr"`
+		Value    string `xml:"val,attr"`
+	} `xml:"customFilter"`
+}
+
+type rawDynamicFilter struct {
+	Type     string  `xml:"type,attr"`
+	Value    float64 `xml:"val,attr"`
+	MaxValue float64 `xml:"maxVal,attr"`
+}
+
+type rawTop10 struct {
+	Top         *bool   `xml:"top,attr"`
+	Percent     bool    `xml:"percent,attr"`
+	Value       float64 `xml:"val,attr"`
+	FilterValue float64 `xml:"filterVal,attr"`
+}
+
+// AutoFilter returns the filter applied to a sheet, or nil if the sheet is not filtered.
+func (x *XlsxFile) AutoFilter(sheet string) (*AutoFilter, error) {
+	var filter *AutoFilter
+
+	err := x.scanSheet(sheet, map[string]elementHandler{"worksheet/autoFilter": autoFilterHandler(&filter)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read auto filter: %w", err)
+	}
+
+	return filter, nil
+}
+
+// autoFilterHandler returns an elementHandler which reads the autoFilter element into filter.
+func autoFilterHandler(filter **AutoFilter) elementHandler {
+	return func(d *xml.Decoder, start xml.StartElement) error {
+		var raw rawAutoFilter
+		if err := d.DecodeElement(&raw, &start); err != nil {
+			return err
+		}
+
+		f, err := newAutoFilter(raw)
+		if err != nil {
+			return err
+		}
+		*filter = &f
+		return nil
+	}
+}
+
+// newAutoFilter converts the raw XML of an auto filter into an AutoFilter.
+func newAutoFilter(raw rawAutoFilter) (AutoFilter, error) {
+	r, err := parseCellRange(raw.Ref)
+	if err != nil {
+		return AutoFilter{}, err
+	}
+
+	filter := AutoFilter{Ref: raw.Ref, Columns: []FilterColumn{}}
+	for _, rc := range raw.FilterColumns {
+		// Columns are identified by their offset from the start of the range
+		column := FilterColumn{Column: columnName(r.FirstColumn + rc.ColumnID)}
+
+		if f := rc.Filters; f != nil {
+			column.Blank = f.Blank
+			for _, v := range f.Filters {
+				column.Values = append(column.Values, v.Value)
+			}
+			for _, g := range f.DateGroups {
+				column.Dates = append(column.Dates, DateGroup(g))
+			}
+		}
+
+		if f := rc.CustomFilters; f != nil {
+			column.MatchAll = f.And
+			for _, c := range f.CustomFilters {
+				operator := c.Operator
+				if operator == "" {
+					operator = "equal"
+				}
+				column.CustomFilters = append(column.CustomFilters, CustomFilter{Operator: operator, Value: c.Value})
+			}
+		}
+
+		if f := rc.Top10; f != nil {
+			column.Top10 = &Top10Filter{
+				Top:         f.Top == nil || *f.Top,
+				Percent:     f.Percent,
+				Value:       f.Value,
+				FilterValue: f.FilterValue,
+			}
+		}
+
+		if f := rc.DynamicFilter; f != nil {
+			dynamic := DynamicFilter(*f)
+			column.Dynamic = &dynamic
+		}
+
+		filter.Columns = append(filter.Columns, column)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,75 @@
+package xlsxreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGettingAutoFilter(t *testing.T) {
+	e, err := OpenFile("test/test-autofilter.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	filter, err := e.AutoFilter("Filtered")
+	require.NoError(t, err)
+	require.Equal(t, &AutoFilter{Ref: "A1:C6", Columns: []FilterColumn{
+		{Column: "A", Values: []string{"10", "12", "14"}},
+		{Column: "B", MatchAll: true, CustomFilters: []CustomFilter{
+			{Operator: "greaterThan", Value: "19"},
+			{Operator: "notEqual", Value: "21"},
+		}},
+		{Column: "C", Top10: &Top10Filter{Top: true, Value: 3, FilterValue: 32}},
+	}}, filter)
+
+	filter, err = e.AutoFilter("Criteria")
+	require.NoError(t, err)
+	require.Equal(t, &AutoFilter{Ref: "B2:E10", Columns: []FilterColumn{
+		{Column: "B", Blank: true, Dates: []DateGroup{{Grouping: "month", Year: 2024, Month: 3}}},
+		{Column: "C", Dynamic: &DynamicFilter{Type: "aboveAverage", Value: 12.5}},
+		{Column: "D", Top10: &Top10Filter{Percent: true, Value: 10, FilterValue: 2}},
+		{Column: "E", CustomFilters: []CustomFilter{{Operator: "equal", Value: "a*"}}},
+	}}, filter)
+
+	// The filter of the custom sheet view is not that of the sheet itself
+	filter, err = e.AutoFilter("CustomView")
+	require.NoError(t, err)
+	require.Equal(t, &AutoFilter{Ref: "A1:A4", Columns: []FilterColumn{}}, filter)
+
+	filter, err = e.AutoFilter("Unfiltered")
+	require.NoError(t, err)
+	require.Nil(t, filter)
+
+	_, err = e.AutoFilter("NonExistent")
+	require.EqualError(t, err, "unable to read auto filter: unable to open sheet NonExistent")
+}
+
+var skippingFilteredRowsTests = []struct {
+	Name     string
+	Sheet    string
+	Options  Options
+	Expected []int
+}{
+	{Name: "Filtered", Sheet: "Filtered", Options: Options{SkipFilteredRows: true}, Expected: []int{1, 2, 4, 6, 8}},
+	{Name: "Filtered and hidden", Sheet: "Filtered", Options: Options{SkipFilteredRows: true, SkipHiddenRows: true}, Expected: []int{1, 2, 4, 6}},
+	{Name: "Filtered densely", Sheet: "Filtered", Options: Options{SkipFilteredRows: true, Dense: true}, Expected: []int{1, 2, 4, 6, 7, 8}},
+	{Name: "Custom sheet view", Sheet: "CustomView", Options: Options{SkipFilteredRows: true}, Expected: []int{1, 2, 4}},
+	{Name: "Unfiltered", Sheet: "Unfiltered", Options: Options{SkipFilteredRows: true}, Expected: []int{1, 2}},
+}
+
+func TestSkippingFilteredRows(t *testing.T) {
+	e, err := OpenFile("test/test-autofilter.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	for _, test := range skippingFilteredRowsTests {
+		t.Run(test.Name, func(t *testing.T) {
+			var indices []int
+			for row := range e.ReadRowsWithOptions(test.Sheet, test.Options) {
+				require.NoError(t, row.Error)
+				indices = append(indices, row.Index)
+			}
+			require.Equal(t, test.Expected, indices)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package xlsxreader
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CellError defines the error values which can be held by an excel cell, typically as the
+// result of a formula which could not be calculated.
+type CellError string
+
+const (
+	// CellErrorNull is for an intersection of two ranges which do not intersect
+	CellErrorNull CellError = "#NULL!"
+	// CellErrorDiv0 is for a division by zero
+	CellErrorDiv0 CellError = "#DIV/0!"
+	// CellErrorValue is for an argument of the wrong type
+	CellErrorValue CellError = "#VALUE!"
+	// CellErrorRef is for a reference to a cell which does not exist
+	CellErrorRef CellError = "#REF!"
+	// CellErrorName is for an unrecognised function or defined name
+	CellErrorName CellError = "#NAME?"
+	// CellErrorNum is for an invalid or unrepresentable number
+	CellErrorNum CellError = "#NUM!"
+	// CellErrorNA is for a value which is not available, e.g. a failed lookup
+	CellErrorNA CellError = "#N/A"
+	// CellErrorGettingData is for a value which is still being calculated or retrieved
+	CellErrorGettingData CellError = "#GETTING_DATA"
+	// CellErrorSpill is for a dynamic array formula which is unable to spill into its range
+	CellErrorSpill CellError = "#SPILL!"
+	// CellErrorCalc is for a calculation the engine does not support, e.g. an empty array
+	CellErrorCalc CellError = "#CALC!"
+	// CellErrorField is for a field of a linked data type which does not exist
+	CellErrorField CellError = "#FIELD!"
+	// CellErrorBlocked is for a feature which has been blocked from being used
+	CellErrorBlocked CellError = "#BLOCKED!"
+	// CellErrorConnect is for an external data source which could not be reached
+	CellErrorConnect CellError = "#CONNECT!"
+	// CellErrorBusy is for a value which the engine is busy calculating
+	CellErrorBusy CellError = "#BUSY!"
+	// CellErrorUnknown is for a data type which the engine does not recognise
+	CellErrorUnknown CellError = "#UNKNOWN!"
+	// CellErrorExternal is for an external function which failed
+	CellErrorExternal CellError = "#EXTERNAL!"
+	// CellErrorPython is for a Python formula which failed
+	CellErrorPython CellError = "#PYTHON!"
+)
+
+// Error allows a CellError to be used as an error, so that it may be checked with errors.Is.
+func (e CellError) Error() string {
+	return string(e)
+}
+
+// CellValueError reports that a cell holds an error value, rather than a usable value.
+type CellValueError struct {
+	Reference string // E.G   A1
+	Value     CellError
+}
+
+func (e *CellValueError) Error() string {
+	return fmt.Sprintf("cell %s contains error %s", e.Reference, e.Value)
+}
+
+// Unwrap gives the CellError held by the cell.
+func (e *CellValueError) Unwrap() error {
+	return e.Value
+}
+
+// surfaceCellErrors moves the value of every error cell within the row onto the cell's Error
+// field, and reports them all together on the row's Error field.
+func surfaceCellErrors(row Row) Row {
+	var errs []error
+	for i, cell := range row.Cells {
+		if cell.Type != TypeError {
+			continue
+		}
+
+		err := &CellValueError{
+			Reference: cell.Reference(),
+			Value:     CellError(cell.Value),
+		}
+		row.Cells[i].Value = ""
+		row.Cells[i].Error = err
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 && row.Error == nil {
+		row.Error = errors.Join(errs...)
+	}
+
+	return row
+}
@@ -0,0 +1,51 @@
+package xlsxreader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadingErrorCellsAsValues(t *testing.T) {
+	e, err := OpenFile("test/test-cell-errors.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var rows []Row
+	for row := range e.ReadRows("Errors") {
+		require.NoError(t, row.Error)
+		rows = append(rows, row)
+	}
+
+	require.Equal(t, []Cell{
+		{Column: "A", Row: 1, Value: "#N/A", Type: TypeString},
+		{Column: "B", Row: 1, Value: "#N/A", Type: TypeError, Formula: "VLOOKUP(A1,C:D,2,FALSE)", FormulaType: FormulaNormal},
+	}, rows[0].Cells)
+}
+
+func TestReadingErrorCellsAsErrors(t *testing.T) {
+	e, err := OpenFile("test/test-cell-errors.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var rows []Row
+	for row := range e.ReadRowsWithOptions("Errors", Options{CellErrorsAsErrors: true}) {
+		rows = append(rows, row)
+	}
+	require.Len(t, rows, 3)
+
+	require.EqualError(t, rows[0].Error, "cell B1 contains error #N/A")
+	require.True(t, errors.Is(rows[0].Error, CellErrorNA))
+	require.Equal(t, "#N/A", rows[0].Cells[0].Value)
+	require.NoError(t, rows[0].Cells[0].Error)
+	require.Equal(t, "", rows[0].Cells[1].Value)
+	require.Equal(t, &CellValueError{Reference: "B1", Value: CellErrorNA}, rows[0].Cells[1].Error)
+
+	require.EqualError(t, rows[1].Error, "cell B2 contains error #DIV/0!\ncell C2 contains error #REF!")
+	require.True(t, errors.Is(rows[1].Error, CellErrorDiv0))
+	require.True(t, errors.Is(rows[1].Error, CellErrorRef))
+	require.False(t, errors.Is(rows[1].Error, CellErrorNA))
+
+	require.NoError(t, rows[2].Error)
+}
@@ -0,0 +1,116 @@
+package xlsxreader
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// typeError describes a cell which cannot be read as the requested kind of value.
+// Error cells are instead described by the error they contain.
+func (c Cell) typeError(kind string) error {
+	if c.Type == TypeError {
+		if c.Error != nil {
+			return c.Error
+		}
+		return &CellValueError{Reference: c.Reference(), Value: CellError(c.Value)}
+	}
+
+	return fmt.Errorf("cell %s has type %s, which cannot be read as %s", c.Reference(), c.Type, kind)
+}
+
+// Float64 gives the value of a numerical or date cell as a number.
+// For dates, this is the underlying serial number of days since the workbook's epoch.
+func (c Cell) Float64() (float64, error) {
+	value := c.Value
+	switch {
+	case c.Type == TypeNumerical:
+	case c.Type == TypeDateTime && c.serial != "":
+		value = c.serial
+	default:
+		return 0, c.typeError("a number")
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse value of cell %s as a number: %w", c.Reference(), err)
+	}
+	return f, nil
+}
+
+// Int64 gives the value of a numerical cell as an integer.
+// An error is returned if the value has a fractional part, or is too large to be represented.
+func (c Cell) Int64() (int64, error) {
+	if c.Type != TypeNumerical {
+		return 0, c.typeError("an integer")
+	}
+
+	if i, err := strconv.ParseInt(c.Value, 10, 64); err == nil {
+		return i, nil
+	}
+
+	f, err := c.Float64()
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("value %s of cell %s is not an integer", c.Value, c.Reference())
+	}
+	return int64(f), nil
+}
+
+// Bool gives the value of a boolean cell.
+func (c Cell) Bool() (bool, error) {
+	if c.Type != TypeBoolean {
+		return false, c.typeError("a boolean")
+	}
+
+	switch c.Value {
+	case "1", "true", "TRUE":
+		return true, nil
+	case "0", "false", "FALSE":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unable to parse value %s of cell %s as a boolean", c.Value, c.Reference())
+	}
+}
+
+// Time gives the value of a date cell as a time in UTC.
+// Unlike Value, the fractions of a second are kept, rather than being truncated.
+func (c Cell) Time() (time.Time, error) {
+	if c.Type != TypeDateTime {
+		return time.Time{}, c.typeError("a time")
+	}
+
+	if c.serial != "" {
+		f, err := c.Float64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		return convertExcelDateToTime(f, c.date1904), nil
+	}
+
+	t, err := parseISODate(c.Value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse value %s of cell %s as a time", c.Value, c.Reference())
+	}
+	return t, nil
+}
+
+// Duration gives the value of a date cell as a duration, such as for a cell formatted as
+// elapsed time, e.g. [h]:mm:ss.
+func (c Cell) Duration() (time.Duration, error) {
+	if c.Type != TypeDateTime {
+		return 0, c.typeError("a duration")
+	}
+	if c.serial == "" {
+		return 0, fmt.Errorf("cell %s holds the date %s, which cannot be read as a duration", c.Reference(), c.Value)
+	}
+
+	f, err := c.Float64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(math.Round(f * nanoSecondsPerDay)), nil
+}
@@ -0,0 +1,113 @@
+package xlsxreader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	numberCell   = Cell{Column: "A", Row: 1, Value: "42.5", Type: TypeNumerical}
+	integerCell  = Cell{Column: "A", Row: 2, Value: "42", Type: TypeNumerical}
+	exponentCell = Cell{Column: "A", Row: 3, Value: "1E+3", Type: TypeNumerical}
+	stringCell   = Cell{Column: "B", Row: 1, Value: "42", Type: TypeString}
+	trueCell     = Cell{Column: "C", Row: 1, Value: "1", Type: TypeBoolean}
+	falseCell    = Cell{Column: "C", Row: 2, Value: "0", Type: TypeBoolean}
+	dateCell     = Cell{Column: "D", Row: 1, Value: "2019-01-24T06:00:00Z", Type: TypeDateTime, serial: "43489.2500001"}
+	date1904Cell = Cell{Column: "D", Row: 2, Value: "2019-01-24", Type: TypeDateTime, serial: "42027", date1904: true}
+	isoDateCell  = Cell{Column: "D", Row: 3, Value: "2019-01-24T06:00:00", Type: TypeDateTime}
+	durationCell = Cell{Column: "D", Row: 4, Value: "1900-01-01T12:00:00Z", Type: TypeDateTime, serial: "1.5"}
+	errorCell    = Cell{Column: "E", Row: 1, Value: "#N/A", Type: TypeError}
+)
+
+func TestReadingCellsAsFloats(t *testing.T) {
+	f, err := numberCell.Float64()
+	require.NoError(t, err)
+	require.Equal(t, 42.5, f)
+
+	f, err = dateCell.Float64()
+	require.NoError(t, err)
+	require.Equal(t, 43489.2500001, f)
+
+	_, err = stringCell.Float64()
+	require.EqualError(t, err, "cell B1 has type string, which cannot be read as a number")
+
+	_, err = errorCell.Float64()
+	require.EqualError(t, err, "cell E1 contains error #N/A")
+}
+
+func TestReadingCellsAsIntegers(t *testing.T) {
+	i, err := integerCell.Int64()
+	require.NoError(t, err)
+	require.Equal(t, int64(42), i)
+
+	i, err = exponentCell.Int64()
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), i)
+
+	_, err = numberCell.Int64()
+	require.EqualError(t, err, "value 42.5 of cell A1 is not an integer")
+
+	_, err = dateCell.Int64()
+	require.EqualError(t, err, "cell D1 has type datetime, which cannot be read as an integer")
+}
+
+func TestReadingCellsAsBooleans(t *testing.T) {
+	b, err := trueCell.Bool()
+	require.NoError(t, err)
+	require.True(t, b)
+
+	b, err = falseCell.Bool()
+	require.NoError(t, err)
+	require.False(t, b)
+
+	_, err = integerCell.Bool()
+	require.EqualError(t, err, "cell A2 has type numerical, which cannot be read as a boolean")
+}
+
+func TestReadingCellsAsTimes(t *testing.T) {
+	tm, err := dateCell.Time()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 1, 24, 6, 0, 0, 8640000, time.UTC), tm.Round(time.Microsecond))
+
+	tm, err = date1904Cell.Time()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 1, 24, 0, 0, 0, 0, time.UTC), tm)
+
+	tm, err = isoDateCell.Time()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 1, 24, 6, 0, 0, 0, time.UTC), tm)
+
+	_, err = numberCell.Time()
+	require.EqualError(t, err, "cell A1 has type numerical, which cannot be read as a time")
+}
+
+func TestReadingCellsAsDurations(t *testing.T) {
+	d, err := durationCell.Duration()
+	require.NoError(t, err)
+	require.Equal(t, 36*time.Hour, d)
+
+	_, err = isoDateCell.Duration()
+	require.EqualError(t, err, "cell D3 holds the date 2019-01-24T06:00:00, which cannot be read as a duration")
+
+	_, err = stringCell.Duration()
+	require.EqualError(t, err, "cell B1 has type string, which cannot be read as a duration")
+}
+
+func TestReadingTypedValuesFromFile(t *testing.T) {
+	e, err := OpenFile("test/test-date1904.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	row := <-e.ReadRows("Dates")
+	require.NoError(t, row.Error)
+
+	tm, err := row.Cells[1].Time()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 1, 24, 6, 0, 0, 0, time.UTC), tm)
+
+	i, err := row.Cells[2].Int64()
+	require.NoError(t, err)
+	require.Equal(t, int64(42027), i)
+}
@@ -0,0 +1,291 @@
+package xlsxreader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// Compound File Binary (CFB) is the container format used by encrypted workbooks, and by
+// workbooks saved in the legacy Excel 97-2003 format. It stores a hierarchy of streams
+// within a file, much like a file system.
+// See https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-cfb
+
+// compoundFileSignature is found at the start of every compound file.
+var compoundFileSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// Special values within the allocation tables of a compound file.
+const (
+	maxRegularSector = 0xFFFFFFFA
+	endOfChain       = 0xFFFFFFFE
+	freeSector       = 0xFFFFFFFF
+	noStream         = 0xFFFFFFFF
+	miniStreamCutoff = 4096 // Streams smaller than this are stored in the mini stream
+)
+
+// Types of directory entry within a compound file.
+const (
+	entryStream = 2
+	entryRoot   = 5
+)
+
+// errStreamNotFound indicates that a compound file does not contain a stream.
+var errStreamNotFound = errors.New("stream not found")
+
+// compoundFile provides access to the streams of a compound file held in memory.
+type compoundFile struct {
+	data           []byte
+	sectorSize     int
+	miniSectorSize int
+	miniCutoff     uint64
+	fat            []uint32 // The allocation table of the file's sectors
+	miniFAT        []uint32 // The allocation table of the sectors of the mini stream
+	miniStream     []byte   // Holds the data of streams smaller than miniCutoff
+	entries        []directoryEntry
+}
+
+// directoryEntry describes a storage or stream within a compound file.
+type directoryEntry struct {
+	Name        string
+	Type        byte
+	Left        uint32
+	Right       uint32
+	Child       uint32
+	StartSector uint32
+	Size        uint64
+}
+
+// isCompoundFile reports whether data holds a compound file.
+func isCompoundFile(data []byte) bool {
+	return bytes.HasPrefix(data, compoundFileSignature)
+}
+
+// openCompoundFile parses the header, allocation tables and directory of a compound file.
+func openCompoundFile(data []byte) (*compoundFile, error) {
+	if len(data) < 512 || !isCompoundFile(data) {
+		return nil, errors.New("not a compound file")
+	}
+
+	le := binary.LittleEndian
+	sectorShift := le.Uint16(data[30:])
+	miniSectorShift := le.Uint16(data[32:])
+	if sectorShift != 9 && sectorShift != 12 {
+		return nil, fmt.Errorf("unsupported sector shift %d", sectorShift)
+	}
+	if miniSectorShift != 6 {
+		return nil, fmt.Errorf("unsupported mini sector shift %d", miniSectorShift)
+	}
+
+	miniCutoff := le.Uint32(data[56:])
+	if miniCutoff != miniStreamCutoff {
+		return nil, fmt.Errorf("unsupported mini stream cutoff %d", miniCutoff)
+	}
+
+	c := &compoundFile{
+		data:           data,
+		sectorSize:     1 << sectorShift,
+		miniSectorSize: 1 << miniSectorShift,
+		miniCutoff:     uint64(miniCutoff),
+	}
+
+	// Sizes are taken from the file, so are checked against its length before anything is
+	// allocated using them
+	numFATSectors := le.Uint32(data[44:])
+	if int64(numFATSectors) > int64(len(data)/c.sectorSize) {
+		return nil, fmt.Errorf("unable to read allocation table: %d sectors is more than the file holds", numFATSectors)
+	}
+	firstDirSector := le.Uint32(data[48:])
+	firstMiniFATSector := le.Uint32(data[60:])
+	firstDIFATSector := le.Uint32(data[68:])
+
+	// The locations of the sectors holding the allocation table are listed in the header,
+	// and if there are more than will fit, in a chain of further sectors.
+	fatSectors := make([]uint32, 0, numFATSectors)
+	for i := 0; i < 109 && uint32(len(fatSectors)) < numFATSectors; i++ {
+		fatSectors = append(fatSectors, le.Uint32(data[76+4*i:]))
+	}
+	perSector := c.sectorSize/4 - 1
+	for sector, seen := firstDIFATSector, 0; sector <= maxRegularSector && uint32(len(fatSectors)) < numFATSectors; seen++ {
+		b, err := c.sector(sector)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read allocation table: %w", err)
+		}
+		if seen > len(data)/c.sectorSize {
+			return nil, errors.New("unable to read allocation table: cycle detected")
+		}
+		for i := 0; i < perSector && uint32(len(fatSectors)) < numFATSectors; i++ {
+			fatSectors = append(fatSectors, le.Uint32(b[4*i:]))
+		}
+		sector = le.Uint32(b[4*perSector:])
+	}
+
+	for _, sector := range fatSectors {
+		b, err := c.sector(sector)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read allocation table: %w", err)
+		}
+		for i := 0; i < c.sectorSize; i += 4 {
+			c.fat = append(c.fat, le.Uint32(b[i:]))
+		}
+	}
+
+	dir, err := c.readChain(firstDirSector, -1)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read directory: %w", err)
+	}
+	for i := 0; i+128 <= len(dir); i += 128 {
+		c.entries = append(c.entries, parseDirectoryEntry(dir[i:i+128], sectorShift == 9))
+	}
+	if len(c.entries) == 0 || c.entries[0].Type != entryRoot {
+		return nil, errors.New("unable to read directory: missing root entry")
+	}
+
+	if firstMiniFATSector <= maxRegularSector {
+		miniFAT, err := c.readChain(firstMiniFATSector, -1)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read mini allocation table: %w", err)
+		}
+		for i := 0; i+4 <= len(miniFAT); i += 4 {
+			c.miniFAT = append(c.miniFAT, le.Uint32(miniFAT[i:]))
+		}
+
+		// The mini stream is held in regular sectors, starting from that of the root entry
+		root := c.entries[0]
+		if root.Size > uint64(len(data)) {
+			return nil, errors.New("unable to read mini stream: size is larger than the file")
+		}
+		if c.miniStream, err = c.readChain(root.StartSector, int(root.Size)); err != nil {
+			return nil, fmt.Errorf("unable to read mini stream: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+// parseDirectoryEntry parses a 128 byte directory entry.
+func parseDirectoryEntry(b []byte, version3 bool) directoryEntry {
+	le := binary.LittleEndian
+
+	nameLength := int(le.Uint16(b[64:]))
+	if nameLength > 64 {
+		nameLength = 64
+	}
+	name := make([]uint16, 0, nameLength/2)
+	for i := 0; i+1 < nameLength; i += 2 {
+		if r := le.Uint16(b[i:]); r != 0 {
+			name = append(name, r)
+		}
+	}
+
+	size := le.Uint64(b[120:])
+	if version3 {
+		// Only the lower 32 bits of the size are used by version 3 files
+		size &= 0xFFFFFFFF
+	}
+
+	return directoryEntry{
+		Name:        string(utf16.Decode(name)),
+		Type:        b[66],
+		Left:        le.Uint32(b[68:]),
+		Right:       le.Uint32(b[72:]),
+		Child:       le.Uint32(b[76:]),
+		StartSector: le.Uint32(b[116:]),
+		Size:        size,
+	}
+}
+
+// sector gives the data of a regular sector.
+func (c *compoundFile) sector(sector uint32) ([]byte, error) {
+	start := (int64(sector) + 1) * int64(c.sectorSize)
+	end := start + int64(c.sectorSize)
+	if sector > maxRegularSector || end > int64(len(c.data)) {
+		return nil, fmt.Errorf("sector %d is out of range", sector)
+	}
+	return c.data[start:end], nil
+}
+
+// readChain reads the regular sectors in a chain, starting from the given sector, until
+// either the end of the chain or size bytes have been read. A negative size reads the
+// whole chain.
+func (c *compoundFile) readChain(sector uint32, size int) ([]byte, error) {
+	var b []byte
+	for sector != endOfChain && (size < 0 || len(b) < size) {
+		if int(sector) >= len(c.fat) || len(b) > len(c.data) {
+			return nil, fmt.Errorf("invalid sector chain at sector %d", sector)
+		}
+		data, err := c.sector(sector)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, data...)
+		sector = c.fat[sector]
+	}
+
+	if size >= 0 {
+		if len(b) < size {
+			return nil, errors.New("sector chain ended early")
+		}
+		b = b[:size]
+	}
+	return b, nil
+}
+
+// readMiniChain reads size bytes from the sectors of the mini stream in a chain, starting
+// from the given sector.
+func (c *compoundFile) readMiniChain(sector uint32, size int) ([]byte, error) {
+	if size > len(c.miniStream) {
+		return nil, errors.New("mini sector chain is larger than the mini stream")
+	}
+
+	b := make([]byte, 0, size)
+	for sector != endOfChain && len(b) < size {
+		start := int(sector) * c.miniSectorSize
+		end := start + c.miniSectorSize
+		if int(sector) >= len(c.miniFAT) || end > len(c.miniStream) {
+			return nil, fmt.Errorf("invalid mini sector chain at sector %d", sector)
+		}
+		b = append(b, c.miniStream[start:end]...)
+		sector = c.miniFAT[sector]
+	}
+
+	if len(b) < size {
+		return nil, errors.New("mini sector chain ended early")
+	}
+	return b[:size], nil
+}
+
+// stream reads the named stream from the root storage of the compound file. Names are
+// compared case-insensitively, as they are by the format.
+func (c *compoundFile) stream(name string) ([]byte, error) {
+	entry, ok := c.find(c.entries[0].Child, name, 0)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errStreamNotFound, name)
+	}
+
+	if entry.Size > uint64(len(c.data)) {
+		return nil, fmt.Errorf("unable to read stream %s: size is larger than the file", name)
+	}
+	if entry.Size < c.miniCutoff {
+		return c.readMiniChain(entry.StartSector, int(entry.Size))
+	}
+	return c.readChain(entry.StartSector, int(entry.Size))
+}
+
+// find searches the tree of sibling entries beneath a storage for the named stream.
+func (c *compoundFile) find(id uint32, name string, depth int) (directoryEntry, bool) {
+	if id == noStream || int(id) >= len(c.entries) || depth > len(c.entries) {
+		return directoryEntry{}, false
+	}
+
+	entry := c.entries[id]
+	if entry.Type == entryStream && strings.EqualFold(entry.Name, name) {
+		return entry, true
+	}
+	if found, ok := c.find(entry.Left, name, depth+1); ok {
+		return found, true
+	}
+	return c.find(entry.Right, name, depth+1)
+}
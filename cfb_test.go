@@ -0,0 +1,53 @@
+package xlsxreader
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var corruptCompoundFileTests = []struct {
+	Name    string
+	Corrupt func(data []byte)
+}{
+	{
+		Name:    "Mini stream cutoff",
+		Corrupt: func(data []byte) { binary.LittleEndian.PutUint32(data[56:], 0x10000) },
+	},
+	{
+		Name:    "Number of allocation table sectors",
+		Corrupt: func(data []byte) { binary.LittleEndian.PutUint32(data[44:], 0x7FFFFFFF) },
+	},
+	{
+		Name: "Size of mini stream",
+		Corrupt: func(data []byte) {
+			dir := (int(binary.LittleEndian.Uint32(data[48:])) + 1) * 512
+			binary.LittleEndian.PutUint32(data[dir+120:], 0x7FFFFFFF)
+		},
+	},
+	{
+		Name: "Size of stream",
+		Corrupt: func(data []byte) {
+			dir := (int(binary.LittleEndian.Uint32(data[48:])) + 1) * 512
+			binary.LittleEndian.PutUint32(data[dir+128+120:], 0x7FFFFFFF)
+		},
+	},
+}
+
+func TestReadingCorruptCompoundFiles(t *testing.T) {
+	for _, test := range corruptCompoundFileTests {
+		t.Run(test.Name, func(t *testing.T) {
+			data, err := os.ReadFile("test/test-legacy.xls")
+			require.NoError(t, err)
+			test.Corrupt(data)
+
+			c, err := openCompoundFile(data)
+			if err == nil {
+				_, err = c.stream(legacyWorkbookStream)
+			}
+			require.Error(t, err)
+		})
+	}
+}
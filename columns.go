@@ -0,0 +1,115 @@
+package xlsxreader
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// ColumnInfo describes the formatting of a range of adjacent columns within a sheet.
+// Columns without any formatting are not described.
+type ColumnInfo struct {
+	FirstColumn  string // E.G   A
+	LastColumn   string
+	Width        float64 // In characters, or zero if the width is not recorded
+	CustomWidth  bool    // Whether the width has been set manually
+	Hidden       bool
+	Style        int // Index of the style applied to the empty cells of the columns
+	OutlineLevel int // The depth of the outline group containing the columns, or zero
+	Collapsed    bool
+}
+
+// rawColumn represents the raw XML element describing a range of columns.
+type rawColumn struct {
+	Min          int     `xml:"min,attr"`
+	Max          int     `xml:"max,attr"`
+	Width        float64 `xml:"width,attr"`
+	CustomWidth  bool    `xml:"customWidth,attr"`
+	Hidden       bool    `xml:"hidden,attr"`
+	Style        int     `xml:"style,attr"`
+	OutlineLevel int     `xml:"outlineLevel,attr"`
+	Collapsed    bool    `xml:"collapsed,attr"`
+}
+
+// Columns returns the formatting of the columns within a sheet, such as their widths and
+// whether they are hidden. Only the top of the sheet is read, as the columns are described
+// before any of the rows.
+func (x *XlsxFile) Columns(sheet string) ([]ColumnInfo, error) {
+	xmlFile, err := x.openSheetFile(sheet)
+	if err != nil {
+		return nil, err
+	}
+	defer xmlFile.Close()
+
+	columns := []ColumnInfo{}
+	decoder := xml.NewDecoder(xmlFile)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return columns, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		startElement, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch startElement.Name.Local {
+		case "sheetData":
+			return columns, nil
+		case "col":
+			column, err := parseColumn(decoder, startElement)
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, column)
+		}
+	}
+}
+
+// parseColumn parses a col element into a ColumnInfo.
+func parseColumn(d *xml.Decoder, start xml.StartElement) (ColumnInfo, error) {
+	var raw rawColumn
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return ColumnInfo{}, fmt.Errorf("unable to parse column: %w", err)
+	}
+	if raw.Min < 1 || raw.Max < raw.Min {
+		return ColumnInfo{}, fmt.Errorf("unable to parse column: invalid range %d:%d", raw.Min, raw.Max)
+	}
+
+	return ColumnInfo{
+		FirstColumn:  columnName(raw.Min - 1),
+		LastColumn:   columnName(raw.Max - 1),
+		Width:        raw.Width,
+		CustomWidth:  raw.CustomWidth,
+		Hidden:       raw.Hidden,
+		Style:        raw.Style,
+		OutlineLevel: raw.OutlineLevel,
+		Collapsed:    raw.Collapsed,
+	}, nil
+}
+
+// dropHiddenColumns removes the cells of a row which lie within any of the hidden columns.
+func dropHiddenColumns(row Row, hidden []ColumnInfo) Row {
+	cells := make([]Cell, 0, len(row.Cells))
+	for _, cell := range row.Cells {
+		if !inColumns(cell.ColumnIndex(), hidden) {
+			cells = append(cells, cell)
+		}
+	}
+	row.Cells = cells
+	return row
+}
+
+// inColumns reports whether the column at a zero based index lies within any of the columns.
+func inColumns(column int, columns []ColumnInfo) bool {
+	for _, c := range columns {
+		if asIndex(c.FirstColumn) <= column && column <= asIndex(c.LastColumn) {
+			return true
+		}
+	}
+	return false
+}
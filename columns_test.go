@@ -0,0 +1,58 @@
+package xlsxreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGettingColumns(t *testing.T) {
+	e, err := OpenFile("test/test-columns.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	columns, err := e.Columns("Columns")
+	require.NoError(t, err)
+	require.Equal(t, []ColumnInfo{
+		{FirstColumn: "A", LastColumn: "A", Width: 20.5, CustomWidth: true},
+		{FirstColumn: "B", LastColumn: "C", Hidden: true, Style: 2, OutlineLevel: 1},
+		{FirstColumn: "E", LastColumn: "E", Width: 9, Collapsed: true},
+	}, columns)
+
+	columns, err = e.Columns("NoColumns")
+	require.NoError(t, err)
+	require.Empty(t, columns)
+
+	_, err = e.Columns("NonExistent")
+	require.EqualError(t, err, "unable to open sheet NonExistent")
+}
+
+func TestSkippingHiddenColumns(t *testing.T) {
+	e, err := OpenFile("test/test-columns.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var rows [][]string
+	for row := range e.ReadRowsWithOptions("Columns", Options{SkipHiddenColumns: true}) {
+		require.NoError(t, row.Error)
+
+		var refs []string
+		for _, cell := range row.Cells {
+			refs = append(refs, cell.Reference())
+		}
+		rows = append(rows, refs)
+	}
+	require.Equal(t, [][]string{{"A1", "D1", "E1"}, {"A3", "D3"}}, rows)
+
+	var values [][]string
+	for row := range e.ReadRowsWithOptions("Columns", Options{SkipHiddenColumns: true, Dense: true}) {
+		require.NoError(t, row.Error)
+
+		var v []string
+		for _, cell := range row.Cells {
+			v = append(v, cell.Value)
+		}
+		values = append(values, v)
+	}
+	require.Equal(t, [][]string{{"1", "", "", "4", "5"}, {"", "", "", "", ""}, {"31", "", "", "34", ""}}, values)
+}
@@ -0,0 +1,190 @@
+package xlsxreader
+
+import (
+	"fmt"
+	"time"
+)
+
+// Comment represents a comment attached to a cell.
+// This is either a note, or a threaded comment along with its replies. Notes do not record
+// when they were created, or whether they have been resolved.
+type Comment struct {
+	Ref      string // E.G   A1
+	Author   string
+	Text     string
+	Created  time.Time
+	Resolved bool
+	Replies  []Comment
+}
+
+// commentsFile is a struct representing the data we care about from a comments xml file,
+// which holds the notes of a sheet.
+type commentsFile struct {
+	Authors  []string     `xml:"authors>author"`
+	Comments []rawComment `xml:"commentList>comment"`
+}
+
+type rawComment struct {
+	Ref      string             `xml:"ref,attr"`
+	AuthorID int                `xml:"authorId,attr"`
+	Text     sharedStringsValue `xml:"text"`
+}
+
+// threadedCommentsFile is a struct representing the data we care about from a threaded
+// comments xml file.
+type threadedCommentsFile struct {
+	Comments []rawThreadedComment `xml:"threadedComment"`
+}
+
+type rawThreadedComment struct {
+	Ref      string `xml:"ref,attr"`
+	Created  string `xml:"dT,attr"`
+	PersonID string `xml:"personId,attr"`
+	ID       string `xml:"id,attr"`
+	ParentID string `xml:"parentId,attr"`
+	Done     bool   `xml:"done,attr"`
+	Text     string `xml:"text"`
+}
+
+// personList is a struct representing the people who have written threaded comments
+// within a workbook.
+type personList struct {
+	Persons []person `xml:"person"`
+}
+
+type person struct {
+	ID          string `xml:"id,attr"`
+	DisplayName string `xml:"displayName,attr"`
+}
+
+// Comments returns the comments within a sheet, keyed by the reference of the cell each is
+// attached to. Threaded comments are returned in preference to the notes Excel writes
+// alongside them for compatibility with older versions.
+func (x *XlsxFile) Comments(sheet string) (map[string]Comment, error) {
+	if x.format != nil {
+		return nil, x.notSupported("comments")
+	}
+
+	rels, err := x.getSheetRelationships(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read comments: %w", err)
+	}
+	sheetName := x.sheetFiles[sheet].Name
+
+	comments := map[string]Comment{}
+
+	threadedRels := getRelationshipsOfType(rels, "threadedComment")
+	if len(threadedRels) > 0 {
+		authors, err := x.getPersons()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read comments: %w", err)
+		}
+
+		for _, rel := range threadedRels {
+			var f threadedCommentsFile
+			if err := unmarshalFile(x.files, resolveRelationshipTarget(sheetName, rel), &f); err != nil {
+				return nil, fmt.Errorf("unable to read threaded comments: %w", err)
+			}
+			if err := addThreadedComments(comments, f.Comments, authors); err != nil {
+				return nil, fmt.Errorf("unable to read threaded comments: %w", err)
+			}
+		}
+	}
+
+	for _, rel := range getRelationshipsOfType(rels, "comments") {
+		var f commentsFile
+		if err := unmarshalFile(x.files, resolveRelationshipTarget(sheetName, rel), &f); err != nil {
+			return nil, fmt.Errorf("unable to read comments: %w", err)
+		}
+
+		for _, c := range f.Comments {
+			if _, ok := comments[c.Ref]; ok {
+				continue
+			}
+
+			comment := Comment{Ref: c.Ref, Text: c.Text.String()}
+			if 0 <= c.AuthorID && c.AuthorID < len(f.Authors) {
+				comment.Author = f.Authors[c.AuthorID]
+			}
+			comments[c.Ref] = comment
+		}
+	}
+
+	return comments, nil
+}
+
+// addThreadedComments adds threads of comments to a map of comments keyed by cell reference.
+// Each reply is added to the thread of the comment it replies to, found by the comment's ID.
+func addThreadedComments(comments map[string]Comment, raw []rawThreadedComment, authors map[string]string) error {
+	threads := map[string]string{} // The references of the threads of comments, keyed by the IDs of their comments
+	for _, c := range raw {
+		if c.ParentID == "" {
+			threads[c.ID] = c.Ref
+		}
+	}
+
+	for _, c := range raw {
+		comment := Comment{
+			Ref:      c.Ref,
+			Author:   authors[c.PersonID],
+			Text:     c.Text,
+			Resolved: c.Done,
+		}
+
+		if c.Created != "" {
+			created, err := parseISODate(c.Created)
+			if err != nil {
+				return fmt.Errorf("unable to parse date of comment on %s: %w", c.Ref, err)
+			}
+			comment.Created = created
+		}
+
+		if c.ParentID == "" {
+			comments[c.Ref] = comment
+			continue
+		}
+
+		ref, ok := threads[c.ParentID]
+		if !ok {
+			return fmt.Errorf("unable to find comment %s replied to on %s", c.ParentID, c.Ref)
+		}
+		thread := comments[ref]
+		thread.Replies = append(thread.Replies, comment)
+		comments[ref] = thread
+	}
+
+	return nil
+}
+
+// getPersons loads the display names of the people who have written threaded comments,
+// keyed by their ID.
+func (x *XlsxFile) getPersons() (map[string]string, error) {
+	rels, err := getPartRelationships(x.files, x.workbookName)
+	if err != nil {
+		return nil, err
+	}
+
+	persons := map[string]string{}
+	for _, rel := range getRelationshipsOfType(rels, "person") {
+		var list personList
+		if err := unmarshalFile(x.files, resolveRelationshipTarget(x.workbookName, rel), &list); err != nil {
+			return nil, fmt.Errorf("unable to read persons: %w", err)
+		}
+
+		for _, p := range list.Persons {
+			persons[p.ID] = p.DisplayName
+		}
+	}
+
+	return persons, nil
+}
+
+// attachComments sets the Comment field of each cell within the row that has a comment.
+func attachComments(row Row, comments map[string]Comment) Row {
+	for i, cell := range row.Cells {
+		if comment, ok := comments[cell.Reference()]; ok {
+			row.Cells[i].Comment = &comment
+		}
+	}
+	return row
+}
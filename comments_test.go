@@ -0,0 +1,90 @@
+package xlsxreader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var expectedThreadedComment = Comment{
+	Ref:      "A1",
+	Author:   "Alex Analyst",
+	Text:     "Is this figure right?",
+	Created:  time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+	Resolved: true,
+	Replies: []Comment{{
+		Ref:     "A1",
+		Author:  "Jo Accountant",
+		Text:    "Yes, checked against the ledger.",
+		Created: time.Date(2024, 3, 2, 14, 0, 0, 0, time.UTC),
+	}},
+}
+
+var expectedNote = Comment{
+	Ref:    "B1",
+	Author: "Sam Reviewer",
+	Text:   "Sam Reviewer:\nLooks too high",
+}
+
+func TestGettingComments(t *testing.T) {
+	e, err := OpenFile("test/test-comments.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	comments, err := e.Comments("Figures")
+	require.NoError(t, err)
+	require.Equal(t, map[string]Comment{
+		"A1": expectedThreadedComment,
+		"B1": expectedNote,
+	}, comments)
+
+	comments, err = e.Comments("Empty")
+	require.NoError(t, err)
+	require.Empty(t, comments)
+
+	_, err = e.Comments("NonExistent")
+	require.EqualError(t, err, "unable to read comments: unable to open sheet NonExistent")
+}
+
+func TestReadingRowsWithComments(t *testing.T) {
+	e, err := OpenFile("test/test-comments.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var rows []Row
+	for row := range e.ReadRowsWithOptions("Figures", Options{Comments: true}) {
+		require.NoError(t, row.Error)
+		rows = append(rows, row)
+	}
+
+	require.Len(t, rows, 1)
+	require.Equal(t, &expectedThreadedComment, rows[0].Cells[0].Comment)
+	require.Equal(t, &expectedNote, rows[0].Cells[1].Comment)
+	require.Nil(t, rows[0].Cells[2].Comment)
+}
+
+func TestAddingRepliesWithoutThread(t *testing.T) {
+	err := addThreadedComments(map[string]Comment{}, []rawThreadedComment{
+		{Ref: "A1", ID: "{C2}", ParentID: "{C1}"},
+	}, map[string]string{})
+
+	require.EqualError(t, err, "unable to find comment {C1} replied to on A1")
+}
+
+func TestAddingRepliesToThreads(t *testing.T) {
+	comments := map[string]Comment{}
+	err := addThreadedComments(comments, []rawThreadedComment{
+		{Ref: "A1", ID: "{C1}", Text: "first"},
+		{Ref: "B2", ID: "{C2}", Text: "second"},
+		{Ref: "A1", ID: "{C3}", ParentID: "{C2}", Text: "reply to second"},
+		{Ref: "A1", ID: "{C4}", ParentID: "{C1}", Text: "reply to first"},
+	}, map[string]string{})
+	require.NoError(t, err)
+
+	// Replies are attached to the thread with the ID they reply to, not the cell they give
+	require.Equal(t, map[string]Comment{
+		"A1": {Ref: "A1", Text: "first", Replies: []Comment{{Ref: "A1", Text: "reply to first"}}},
+		"B2": {Ref: "B2", Text: "second", Replies: []Comment{{Ref: "A1", Text: "reply to second"}}},
+	}, comments)
+}
@@ -14,29 +14,107 @@ const nanoSecondsPerDay = 24 * 60 * 60 * 1000 * 1000 * 1000
 // as relative to this value.
 var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
 
+// excelEpoch1904 specifies the epoch of excel dates within workbooks using the 1904 date
+// system, as was the default for older versions of Excel for Mac.
+var excelEpoch1904 = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+
 // convertExcelDateToDateString takes an excel numeric representation of a date, and
 // converts it to a human-readable RFC3339 or ISO formatted string.
 //
 // Excel dates are stored within excel as a signed floating point number.
-// The integer portion determines the number of days ahead of 30/12/1899 the date is.
+// The integer portion determines the number of days ahead of 30/12/1899 the date is,
+// or ahead of 01/01/1904 if the workbook uses the 1904 date system.
 // The portion after the decimal point represents the proportion through the day.
 // For example, 6am would be 1/4 of the way through a 24hr day, so it is stored as 0.25.
-func convertExcelDateToDateString(value string) (string, error) {
+func convertExcelDateToDateString(value string, date1904 bool) (string, error) {
 	floatValue, err := strconv.ParseFloat(value, 64)
 	if err != nil {
 		return "", fmt.Errorf("unable to parse date float value: %w", err)
 	}
 
-	numberOfDays := math.Trunc(floatValue)
-	numberOfNanoSeconds := (floatValue - numberOfDays) * nanoSecondsPerDay
-
-	actualTime := excelEpoch.AddDate(0, 0, int(numberOfDays)).Add(time.Duration(numberOfNanoSeconds))
+	actualTime := convertExcelDateToTime(floatValue, date1904)
 
 	formatString := time.RFC3339
-	if floatValue == numberOfDays {
+	if floatValue == math.Trunc(floatValue) {
 		// We are dealing with a date, and not a datetime
 		formatString = "2006-01-02"
 	}
 
 	return actualTime.Format(formatString), nil
 }
+
+// isoDateLayouts are the layouts of ISO 8601 dates which may be stored within a workbook as text.
+var isoDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"15:04:05.999999999",
+}
+
+// parseISODate parses a date stored within a workbook as ISO 8601 text. Dates without a
+// time zone are taken to be in UTC.
+func parseISODate(value string) (time.Time, error) {
+	for _, layout := range isoDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse %q as an ISO 8601 date", value)
+}
+
+// convertISODateToDateString takes a date stored as ISO 8601 text, as strict workbooks store
+// dates, and formats it in the same way as convertExcelDateToDateString, also giving the serial
+// number of the date. Times without a date are taken to be on the day of the epoch.
+func convertISODateToDateString(value string, date1904 bool) (string, string, error) {
+	t, err := parseISODate(value)
+	if err != nil {
+		return "", "", err
+	}
+
+	epoch := excelEpoch
+	if date1904 {
+		epoch = excelEpoch1904
+	}
+	if t.Year() == 0 {
+		t = epoch.Add(t.Sub(t.Truncate(24 * time.Hour)))
+	}
+
+	formatted, serial := formatDate(t, date1904)
+	return formatted, serial, nil
+}
+
+// formatDate formats a time as convertExcelDateToDateString formats the serial number of the
+// time, giving both. Times at midnight are formatted as dates.
+func formatDate(t time.Time, date1904 bool) (string, string) {
+	epoch := excelEpoch
+	if date1904 {
+		epoch = excelEpoch1904
+	}
+
+	t = t.UTC()
+	seconds := t.Unix() - epoch.Unix()
+	serial := float64(seconds)/(24*60*60) + float64(t.Nanosecond())/nanoSecondsPerDay
+
+	formatString := time.RFC3339
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		// We are dealing with a date, and not a datetime
+		formatString = "2006-01-02"
+	}
+
+	return t.Format(formatString), strconv.FormatFloat(serial, 'f', -1, 64)
+}
+
+// convertExcelDateToTime takes an excel numeric representation of a date, and converts it
+// to a time in UTC.
+func convertExcelDateToTime(value float64, date1904 bool) time.Time {
+	numberOfDays := math.Trunc(value)
+	numberOfNanoSeconds := (value - numberOfDays) * nanoSecondsPerDay
+
+	epoch := excelEpoch
+	if date1904 {
+		epoch = excelEpoch1904
+	}
+
+	return epoch.AddDate(0, 0, int(numberOfDays)).Add(time.Duration(numberOfNanoSeconds))
+}
@@ -23,7 +23,28 @@ var excelDateTests = []struct {
 func TestConvertingValidExcelDates(t *testing.T) {
 	for _, test := range excelDateTests {
 		t.Run("ValidExcel-"+test.input, func(t *testing.T) {
-			actual, err := convertExcelDateToDateString(test.input)
+			actual, err := convertExcelDateToDateString(test.input, false)
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+var excelDate1904Tests = []struct {
+	input    string
+	expected string
+}{
+	{"0", "1904-01-01"},
+	{"42027", "2019-01-24"},
+	{"42027.25", "2019-01-24T06:00:00Z"},
+	{"-1", "1903-12-31"},
+}
+
+func TestConvertingValidExcel1904Dates(t *testing.T) {
+	for _, test := range excelDate1904Tests {
+		t.Run("ValidExcel1904-"+test.input, func(t *testing.T) {
+			actual, err := convertExcelDateToDateString(test.input, true)
 
 			require.NoError(t, err)
 			require.Equal(t, test.expected, actual)
@@ -39,9 +60,36 @@ var invalidDateTests = []string{
 func TestConvertingInvalidExcelDates(t *testing.T) {
 	for _, test := range invalidDateTests {
 		t.Run("InvalidExcel-"+test, func(t *testing.T) {
-			_, err := convertExcelDateToDateString(test)
+			_, err := convertExcelDateToDateString(test, false)
 
 			require.Error(t, err)
 		})
 	}
 }
+
+var isoDateTests = []struct {
+	input    string
+	date1904 bool
+	expected string
+	serial   string
+}{
+	{"2019-01-24", false, "2019-01-24", "43489"},
+	{"2019-01-24T00:00:00", false, "2019-01-24", "43489"},
+	{"2019-01-24T06:00:00", false, "2019-01-24T06:00:00Z", "43489.25"},
+	{"2019-01-24T07:00:00+01:00", false, "2019-01-24T06:00:00Z", "43489.25"},
+	{"2019-01-24T06:00:00", true, "2019-01-24T06:00:00Z", "42027.25"},
+	{"06:00:00", false, "1899-12-30T06:00:00Z", "0.25"},
+	{"06:00:00", true, "1904-01-01T06:00:00Z", "0.25"},
+}
+
+func TestConvertingISODates(t *testing.T) {
+	for _, test := range isoDateTests {
+		t.Run("ISO-"+test.input, func(t *testing.T) {
+			actual, serial, err := convertISODateToDateString(test.input, test.date1904)
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, actual)
+			require.Equal(t, test.serial, serial)
+		})
+	}
+}
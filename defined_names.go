@@ -0,0 +1,105 @@
+package xlsxreader
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefinedName represents a name defined within a workbook, referring to a range of cells,
+// a constant or a formula.
+type DefinedName struct {
+	Name    string // E.G   SalesTotals, _xlnm.Print_Area
+	Sheet   string // The sheet the name is scoped to, empty if it is available to the whole workbook
+	Formula string // What the name refers to, E.G   Sheet1!$A$1:$C$3
+	Hidden  bool
+}
+
+// BuiltIn reports whether the name is one defined by Excel itself, such as the print area
+// of a sheet (_xlnm.Print_Area), or the range of its auto filter (_xlnm._FilterDatabase).
+func (n DefinedName) BuiltIn() bool {
+	return strings.HasPrefix(n.Name, "_xlnm.")
+}
+
+// rawDefinedName is a struct representing the definedName xml element.
+type rawDefinedName struct {
+	Name         string `xml:"name,attr"`
+	LocalSheetID *int   `xml:"localSheetId,attr"`
+	Hidden       bool   `xml:"hidden,attr"`
+	Formula      string `xml:",chardata"`
+}
+
+// getDefinedNames converts the defined names of a workbook into a consumable format, with
+// sheet scoped names given the name of their sheet.
+func getDefinedNames(wb *workbook) []DefinedName {
+	names := make([]DefinedName, 0, len(wb.DefinedNames))
+
+	for _, raw := range wb.DefinedNames {
+		name := DefinedName{
+			Name:    raw.Name,
+			Formula: raw.Formula,
+			Hidden:  raw.Hidden,
+		}
+		if raw.LocalSheetID != nil && 0 <= *raw.LocalSheetID && *raw.LocalSheetID < len(wb.Sheets) {
+			name.Sheet = wb.Sheets[*raw.LocalSheetID].Name
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// getDefinedName finds a defined name. Names scoped to a sheet are found by qualifying the
+// name with the sheet, e.g. Sheet1!Totals, otherwise only names scoped to the whole
+// workbook are found. As in Excel, names are not case sensitive.
+func (x *XlsxFile) getDefinedName(name string) (DefinedName, error) {
+	sheet, local := splitSheetReference(name)
+
+	for _, n := range x.DefinedNames {
+		if n.Sheet == sheet && strings.EqualFold(n.Name, local) {
+			return n, nil
+		}
+	}
+
+	return DefinedName{}, fmt.Errorf("unable to find defined name %s", name)
+}
+
+// resolveNamedRange finds the sheet and range of cells that a defined name refers to.
+// An error is returned if the name refers to anything other than a single rectangular range.
+func (x *XlsxFile) resolveNamedRange(name string) (string, cellRange, error) {
+	n, err := x.getDefinedName(name)
+	if err != nil {
+		return "", cellRange{}, err
+	}
+
+	sheet, ref := splitSheetReference(strings.TrimPrefix(n.Formula, "="))
+	if sheet == "" {
+		sheet = n.Sheet
+	}
+	if _, ok := x.sheetFiles[sheet]; !ok {
+		return "", cellRange{}, fmt.Errorf("defined name %s does not refer to a range within a worksheet: %s", n.Name, n.Formula)
+	}
+
+	r, err := parseCellRange(ref)
+	if err != nil {
+		return "", cellRange{}, fmt.Errorf("defined name %s does not refer to a plain rectangular range: %s", n.Name, n.Formula)
+	}
+
+	return sheet, r, nil
+}
+
+// ReadNamedRange provides an interface allowing the rows of a range of cells referred to by a
+// defined name to be streamed, in the same way as ReadRows. Only the cells within the range
+// are read, and they keep their position within the sheet.
+//
+// Names scoped to a single sheet can be read by qualifying them with the sheet's name, e.g.
+// Sheet1!Totals. If the name cannot be found, or it refers to anything other than a single
+// rectangular range of cells, a single Row is sent with its Error set.
+func (x *XlsxFile) ReadNamedRange(name string) chan Row {
+	sheet, r, err := x.resolveNamedRange(name)
+	if err != nil {
+		return errorRows(err)
+	}
+
+	return x.ReadRowsWithOptions(sheet, Options{bounds: &r})
+}
@@ -0,0 +1,78 @@
+package xlsxreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGettingDefinedNames(t *testing.T) {
+	e, err := OpenFile("test/test-defined-names.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	require.Equal(t, []DefinedName{
+		{Name: "_xlnm._FilterDatabase", Sheet: "Input Data", Formula: "'Input Data'!$A$1:$D$5", Hidden: true},
+		{Name: "_xlnm.Print_Area", Sheet: "Other", Formula: "Other!$A$1:$B$2"},
+		{Name: "Block", Formula: "'Input Data'!$B$2:$C$3"},
+		{Name: "Single", Formula: "'Input Data'!$D$4"},
+		{Name: "Local", Sheet: "Other", Formula: "Other!$A$1"},
+		{Name: "Rate", Formula: "0.2"},
+		{Name: "Union", Formula: "'Input Data'!$A$1,'Input Data'!$C$3"},
+	}, e.DefinedNames)
+
+	require.True(t, e.DefinedNames[0].BuiltIn())
+	require.False(t, e.DefinedNames[2].BuiltIn())
+}
+
+func readNamedRangeValues(t *testing.T, e *XlsxFileCloser, name string) ([][]string, error) {
+	var values [][]string
+	for row := range e.ReadNamedRange(name) {
+		if row.Error != nil {
+			return values, row.Error
+		}
+
+		var record []string
+		for _, cell := range row.Cells {
+			record = append(record, cell.Reference()+"="+cell.Value)
+		}
+		values = append(values, record)
+	}
+	return values, nil
+}
+
+func TestReadingNamedRanges(t *testing.T) {
+	e, err := OpenFile("test/test-defined-names.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	values, err := readNamedRangeValues(t, e, "Block")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"B2=21", "C2=22"}, {"B3=31", "C3=32"}}, values)
+
+	values, err = readNamedRangeValues(t, e, "single")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"D4=43"}}, values)
+
+	values, err = readNamedRangeValues(t, e, "Other!Local")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"A1=1"}}, values)
+}
+
+func TestReadingInvalidNamedRanges(t *testing.T) {
+	e, err := OpenFile("test/test-defined-names.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	_, err = readNamedRangeValues(t, e, "Missing")
+	require.EqualError(t, err, "unable to find defined name Missing")
+
+	_, err = readNamedRangeValues(t, e, "Local")
+	require.EqualError(t, err, "unable to find defined name Local")
+
+	_, err = readNamedRangeValues(t, e, "Rate")
+	require.EqualError(t, err, "defined name Rate does not refer to a range within a worksheet: 0.2")
+
+	_, err = readNamedRangeValues(t, e, "Union")
+	require.EqualError(t, err, "defined name Union does not refer to a plain rectangular range: 'Input Data'!$A$1,'Input Data'!$C$3")
+}
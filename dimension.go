@@ -0,0 +1,116 @@
+package xlsxreader
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNoDimension indicates that a sheet does not declare the range of cells it uses.
+var ErrNoDimension = errors.New("sheet has no dimension")
+
+// Dimension describes the range of cells used within a sheet, as declared by the sheet.
+type Dimension struct {
+	Ref         string // E.G   A1:C10
+	FirstColumn string
+	FirstRow    int
+	LastColumn  string
+	LastRow     int
+}
+
+// Rows gives the number of rows spanned by the dimension.
+func (d Dimension) Rows() int {
+	return d.LastRow - d.FirstRow + 1
+}
+
+// Columns gives the number of columns spanned by the dimension.
+func (d Dimension) Columns() int {
+	return asIndex(d.LastColumn) - asIndex(d.FirstColumn) + 1
+}
+
+// SheetDimension reads the range of cells used within a sheet, as declared at the top of the
+// sheet, without reading any of its rows. As the dimension is written by the application
+// which saved the file, it may include empty rows and columns which have been formatted.
+// If the sheet does not declare a dimension, ErrNoDimension is returned.
+func (x *XlsxFile) SheetDimension(sheet string) (Dimension, error) {
+	xmlFile, err := x.openSheetFile(sheet)
+	if err != nil {
+		return Dimension{}, err
+	}
+	defer xmlFile.Close()
+
+	decoder := xml.NewDecoder(xmlFile)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return Dimension{}, ErrNoDimension
+		}
+		if err != nil {
+			return Dimension{}, fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		startElement, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch startElement.Name.Local {
+		case "sheetData":
+			return Dimension{}, ErrNoDimension
+		case "dimension":
+			return newDimension(startElement)
+		}
+	}
+}
+
+// newDimension parses the range of a dimension element, such as <dimension ref="A1:C3"/>.
+func newDimension(start xml.StartElement) (Dimension, error) {
+	for _, attr := range start.Attr {
+		if attr.Name.Local != "ref" {
+			continue
+		}
+
+		r, err := parseCellRange(attr.Value)
+		if err != nil {
+			return Dimension{}, fmt.Errorf("unable to parse dimension: %w", err)
+		}
+
+		return Dimension{
+			Ref:         attr.Value,
+			FirstColumn: columnName(r.FirstColumn),
+			FirstRow:    r.FirstRow,
+			LastColumn:  columnName(r.LastColumn),
+			LastRow:     r.LastRow,
+		}, nil
+	}
+
+	return Dimension{}, ErrNoDimension
+}
+
+// CountRows counts the row elements within a sheet, without interpreting any of the cells.
+// Note that this includes rows without any values, which ReadRows skips, such as rows
+// which have only been formatted.
+func (x *XlsxFile) CountRows(sheet string) (int, error) {
+	xmlFile, err := x.openSheetFile(sheet)
+	if err != nil {
+		return 0, err
+	}
+	defer xmlFile.Close()
+
+	count := 0
+	decoder := xml.NewDecoder(xmlFile)
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		if startElement, ok := token.(xml.StartElement); ok && startElement.Name.Local == "row" {
+			count++
+		}
+	}
+}
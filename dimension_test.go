@@ -0,0 +1,45 @@
+package xlsxreader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGettingSheetDimension(t *testing.T) {
+	e, err := OpenFile("test/test-small.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	dimension, err := e.SheetDimension("datarefinery_groundtruth_400000")
+	require.NoError(t, err)
+	require.Equal(t, Dimension{Ref: "A1:T4", FirstColumn: "A", FirstRow: 1, LastColumn: "T", LastRow: 4}, dimension)
+	require.Equal(t, 4, dimension.Rows())
+	require.Equal(t, 20, dimension.Columns())
+}
+
+func TestGettingMissingSheetDimension(t *testing.T) {
+	e, err := OpenFile("test/test-dense.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	_, err = e.SheetDimension("NoDimension")
+	require.True(t, errors.Is(err, ErrNoDimension))
+
+	_, err = e.SheetDimension("NonExistent")
+	require.EqualError(t, err, "unable to open sheet NonExistent")
+}
+
+func TestCountingRows(t *testing.T) {
+	e, err := OpenFile("test/test-dense.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	count, err := e.CountRows("Sparse")
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	_, err = e.CountRows("NonExistent")
+	require.EqualError(t, err, "unable to open sheet NonExistent")
+}
@@ -0,0 +1,412 @@
+package xlsxreader
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash"
+	"unicode/utf16"
+)
+
+// ErrEncrypted indicates that a workbook has been encrypted with a password, and so must be
+// opened with OpenFileWithPassword or NewReaderWithPassword.
+var ErrEncrypted = errors.New("workbook is encrypted with a password")
+
+// ErrIncorrectPassword indicates that the password given for an encrypted workbook is wrong.
+var ErrIncorrectPassword = errors.New("incorrect password")
+
+// Encrypted workbooks are stored as a compound file, with the zip archive of the workbook
+// encrypted within the EncryptedPackage stream, and the means of decrypting it described
+// by the EncryptionInfo stream.
+// See https://learn.microsoft.com/en-us/openspecs/office_file_formats/ms-offcrypto
+const (
+	encryptionInfoStream   = "EncryptionInfo"
+	encryptedPackageStream = "EncryptedPackage"
+)
+
+// Block keys used to derive the keys for each of the values of an agile encrypted key.
+var (
+	blockKeyVerifierHashInput = []byte{0xfe, 0xa7, 0xd2, 0x76, 0x3b, 0x4b, 0x9e, 0x79}
+	blockKeyVerifierHashValue = []byte{0xd7, 0xaa, 0x0f, 0x6d, 0x30, 0x61, 0x34, 0x4e}
+	blockKeyEncryptedKey      = []byte{0x14, 0x6e, 0x0b, 0xe7, 0xab, 0xac, 0xd0, 0xd6}
+)
+
+const (
+	passwordKeyEncryptor = "http://schemas.microsoft.com/office/2006/keyEncryptor/password"
+	agileSegmentSize     = 4096
+	standardSpinCount    = 50000
+	maxSpinCount         = 10000000 // The most times a password may be hashed, as limited by the spec
+)
+
+// isEncrypted reports whether data holds an encrypted workbook.
+func isEncrypted(data []byte) bool {
+	if !isCompoundFile(data) {
+		return false
+	}
+	c, err := openCompoundFile(data)
+	if err != nil {
+		return false
+	}
+	_, err = c.stream(encryptionInfoStream)
+	return err == nil
+}
+
+// decryptPackage decrypts the zip archive of an encrypted workbook using its password.
+// Both agile encryption, used by Excel 2010 onwards, and standard encryption, used by
+// Excel 2007, are supported.
+func decryptPackage(data []byte, password string) ([]byte, error) {
+	c, err := openCompoundFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read encrypted file: %w", err)
+	}
+
+	info, err := c.stream(encryptionInfoStream)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read encryption info: %w", err)
+	}
+	pkg, err := c.stream(encryptedPackageStream)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read encrypted package: %w", err)
+	}
+	if len(info) < 8 || len(pkg) < 8 {
+		return nil, errors.New("unable to read encrypted file: streams are truncated")
+	}
+
+	major, minor := binary.LittleEndian.Uint16(info), binary.LittleEndian.Uint16(info[2:])
+	switch {
+	case major == 4 && minor == 4:
+		return decryptAgile(info[8:], pkg, password)
+	case (major == 2 || major == 3 || major == 4) && minor == 2:
+		return decryptStandard(info[8:], pkg, password)
+	default:
+		return nil, fmt.Errorf("unsupported encryption version %d.%d", major, minor)
+	}
+}
+
+// agileKeyData describes the cipher and hash used to encrypt data.
+type agileKeyData struct {
+	SaltSize        int    `xml:"saltSize,attr"`
+	BlockSize       int    `xml:"blockSize,attr"`
+	KeyBits         int    `xml:"keyBits,attr"`
+	HashSize        int    `xml:"hashSize,attr"`
+	CipherAlgorithm string `xml:"cipherAlgorithm,attr"`
+	CipherChaining  string `xml:"cipherChaining,attr"`
+	HashAlgorithm   string `xml:"hashAlgorithm,attr"`
+	SaltValue       string `xml:"saltValue,attr"`
+}
+
+// validate checks that the key data describes a cipher and hash which are supported, and
+// that the sizes given match them, before they are used to size any of the values derived.
+func (kd agileKeyData) validate() error {
+	if kd.CipherAlgorithm != "AES" || kd.CipherChaining != "ChainingModeCBC" {
+		return fmt.Errorf("unsupported cipher %s with %s", kd.CipherAlgorithm, kd.CipherChaining)
+	}
+	if kd.KeyBits != 128 && kd.KeyBits != 192 && kd.KeyBits != 256 {
+		return fmt.Errorf("unsupported key size %d", kd.KeyBits)
+	}
+	if kd.BlockSize != aes.BlockSize {
+		return fmt.Errorf("unsupported block size %d", kd.BlockSize)
+	}
+	if kd.SaltSize != 16 {
+		return fmt.Errorf("unsupported salt size %d", kd.SaltSize)
+	}
+
+	newHash, err := getHash(kd.HashAlgorithm)
+	if err != nil {
+		return err
+	}
+	if kd.HashSize != newHash().Size() {
+		return fmt.Errorf("hash size %d does not match %s", kd.HashSize, kd.HashAlgorithm)
+	}
+	return nil
+}
+
+// agileEncryptedKey holds the key used to encrypt the package, encrypted with the password.
+type agileEncryptedKey struct {
+	agileKeyData
+	SpinCount                  int    `xml:"spinCount,attr"`
+	EncryptedVerifierHashInput string `xml:"encryptedVerifierHashInput,attr"`
+	EncryptedVerifierHashValue string `xml:"encryptedVerifierHashValue,attr"`
+	EncryptedKeyValue          string `xml:"encryptedKeyValue,attr"`
+}
+
+type agileEncryption struct {
+	KeyData       agileKeyData `xml:"keyData"`
+	KeyEncryptors []struct {
+		URI          string            `xml:"uri,attr"`
+		EncryptedKey agileEncryptedKey `xml:"encryptedKey"`
+	} `xml:"keyEncryptors>keyEncryptor"`
+}
+
+// decryptAgile decrypts a package encrypted with agile encryption, where the encryption is
+// described by an XML document.
+// Note that the HMAC of the package is not verified.
+func decryptAgile(info []byte, pkg []byte, password string) ([]byte, error) {
+	var encryption agileEncryption
+	if err := xml.Unmarshal(info, &encryption); err != nil {
+		return nil, fmt.Errorf("unable to parse encryption info: %w", err)
+	}
+
+	var key *agileEncryptedKey
+	for i := range encryption.KeyEncryptors {
+		if encryption.KeyEncryptors[i].URI == passwordKeyEncryptor {
+			key = &encryption.KeyEncryptors[i].EncryptedKey
+		}
+	}
+	if key == nil {
+		return nil, errors.New("unsupported encryption: workbook is not encrypted with a password")
+	}
+
+	for _, kd := range []agileKeyData{key.agileKeyData, encryption.KeyData} {
+		if err := kd.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if key.SpinCount < 0 || key.SpinCount > maxSpinCount {
+		return nil, fmt.Errorf("unsupported spin count %d", key.SpinCount)
+	}
+
+	newHash, err := getHash(key.HashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	salt, err := base64.StdEncoding.DecodeString(key.SaltValue)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode salt: %w", err)
+	}
+
+	// The password is hashed repeatedly, and then combined with a block key to give the key
+	// for decrypting each of the values of the encrypted key
+	h := hashPassword(newHash, salt, password, key.SpinCount)
+	decryptValue := func(blockKey []byte, value string) ([]byte, error) {
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode encrypted key: %w", err)
+		}
+		k := resize(hashOf(newHash, h, blockKey), key.KeyBits/8, 0x36)
+		return decryptCBC(k, resize(salt, key.BlockSize, 0x36), b)
+	}
+
+	verifierInput, err := decryptValue(blockKeyVerifierHashInput, key.EncryptedVerifierHashInput)
+	if err != nil {
+		return nil, err
+	}
+	verifierHash, err := decryptValue(blockKeyVerifierHashValue, key.EncryptedVerifierHashValue)
+	if err != nil {
+		return nil, err
+	}
+	if len(verifierInput) < key.SaltSize || len(verifierHash) < key.HashSize {
+		return nil, errors.New("unable to verify password: verifier is truncated")
+	}
+	expected := hashOf(newHash, verifierInput[:key.SaltSize])
+	if !bytes.Equal(expected, verifierHash[:key.HashSize]) {
+		return nil, ErrIncorrectPassword
+	}
+
+	secret, err := decryptValue(blockKeyEncryptedKey, key.EncryptedKeyValue)
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) < key.KeyBits/8 {
+		return nil, errors.New("unable to decrypt key: key is truncated")
+	}
+	secret = secret[:key.KeyBits/8]
+
+	// The package is encrypted in segments, each with its own initialisation vector
+	keyData := encryption.KeyData
+	dataHash, err := getHash(keyData.HashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	dataSalt, err := base64.StdEncoding.DecodeString(keyData.SaltValue)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode salt: %w", err)
+	}
+
+	size := binary.LittleEndian.Uint64(pkg)
+	encrypted := pkg[8:]
+	decrypted := make([]byte, 0, len(encrypted))
+	for i := 0; i*agileSegmentSize < len(encrypted); i++ {
+		segment := encrypted[i*agileSegmentSize : min((i+1)*agileSegmentSize, len(encrypted))]
+
+		index := make([]byte, 4)
+		binary.LittleEndian.PutUint32(index, uint32(i))
+		iv := resize(hashOf(dataHash, dataSalt, index), keyData.BlockSize, 0x36)
+
+		b, err := decryptCBC(secret, iv, segment)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decrypt package: %w", err)
+		}
+		decrypted = append(decrypted, b...)
+	}
+
+	if uint64(len(decrypted)) < size {
+		return nil, errors.New("unable to decrypt package: package is truncated")
+	}
+	return decrypted[:size], nil
+}
+
+// decryptStandard decrypts a package encrypted with standard encryption, where the
+// encryption is described by a binary header and verifier.
+func decryptStandard(info []byte, pkg []byte, password string) ([]byte, error) {
+	le := binary.LittleEndian
+
+	if len(info) < 4 {
+		return nil, errors.New("unable to parse encryption info: header is truncated")
+	}
+	headerSize := int(le.Uint32(info))
+	if headerSize < 32 || len(info) < 4+headerSize+40 {
+		return nil, errors.New("unable to parse encryption info: header is truncated")
+	}
+	header, verifier := info[4:4+headerSize], info[4+headerSize:]
+
+	algorithm, keySize := le.Uint32(header[8:]), int(le.Uint32(header[16:]))
+	switch algorithm {
+	case 0x660E, 0x660F, 0x6610: // AES-128, AES-192 and AES-256
+	default:
+		return nil, fmt.Errorf("unsupported cipher algorithm %#x", algorithm)
+	}
+	if keySize != 128 && keySize != 192 && keySize != 256 {
+		return nil, fmt.Errorf("unsupported key size %d", keySize)
+	}
+
+	saltSize := int(le.Uint32(verifier))
+	if saltSize != 16 || len(verifier) < 40+32 {
+		return nil, errors.New("unable to parse encryption info: verifier is truncated")
+	}
+	salt := verifier[4:20]
+	encryptedVerifier := verifier[20:36]
+	verifierHashSize := int(le.Uint32(verifier[36:]))
+	encryptedVerifierHash := verifier[40:72]
+
+	// The key is derived from a hash of the password, as CryptDeriveKey does
+	block := make([]byte, 4)
+	h := hashOf(sha1.New, hashPassword(sha1.New, salt, password, standardSpinCount), block)
+	x1 := hashOf(sha1.New, xorBytes(resize(h, 64, 0), 0x36))
+	x2 := hashOf(sha1.New, xorBytes(resize(h, 64, 0), 0x5c))
+	key := append(x1, x2...)[:keySize/8]
+
+	decryptedVerifier, err := decryptECB(key, encryptedVerifier)
+	if err != nil {
+		return nil, err
+	}
+	decryptedVerifierHash, err := decryptECB(key, encryptedVerifierHash)
+	if err != nil {
+		return nil, err
+	}
+	if verifierHashSize > len(decryptedVerifierHash) ||
+		!bytes.Equal(hashOf(sha1.New, decryptedVerifier), decryptedVerifierHash[:verifierHashSize]) {
+		return nil, ErrIncorrectPassword
+	}
+
+	size := le.Uint64(pkg)
+	encrypted := pkg[8:]
+	decrypted, err := decryptECB(key, encrypted[:len(encrypted)-len(encrypted)%aes.BlockSize])
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt package: %w", err)
+	}
+	if uint64(len(decrypted)) < size {
+		return nil, errors.New("unable to decrypt package: package is truncated")
+	}
+	return decrypted[:size], nil
+}
+
+// getHash gives the hash function for the name of a hash algorithm.
+func getHash(name string) (func() hash.Hash, error) {
+	switch name {
+	case "SHA1", "SHA-1":
+		return sha1.New, nil
+	case "SHA256":
+		return sha256.New, nil
+	case "SHA384":
+		return sha512.New384, nil
+	case "SHA512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm %s", name)
+	}
+}
+
+// hashOf hashes the concatenation of the given values.
+func hashOf(newHash func() hash.Hash, values ...[]byte) []byte {
+	h := newHash()
+	for _, v := range values {
+		h.Write(v)
+	}
+	return h.Sum(nil)
+}
+
+// hashPassword hashes a salted password, then repeatedly hashes the result along with the
+// number of the iteration.
+func hashPassword(newHash func() hash.Hash, salt []byte, password string, spinCount int) []byte {
+	encoded := utf16.Encode([]rune(password))
+	b := make([]byte, 2*len(encoded))
+	for i, r := range encoded {
+		binary.LittleEndian.PutUint16(b[2*i:], r)
+	}
+
+	h := hashOf(newHash, salt, b)
+	iterator := make([]byte, 4)
+	for i := 0; i < spinCount; i++ {
+		binary.LittleEndian.PutUint32(iterator, uint32(i))
+		h = hashOf(newHash, iterator, h)
+	}
+	return h
+}
+
+// resize truncates b to size bytes, or pads it to size bytes with the given padding.
+func resize(b []byte, size int, padding byte) []byte {
+	if len(b) >= size {
+		return b[:size]
+	}
+	return append(append([]byte{}, b...), bytes.Repeat([]byte{padding}, size-len(b))...)
+}
+
+func xorBytes(b []byte, x byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[i] = b[i] ^ x
+	}
+	return out
+}
+
+func decryptCBC(key, iv, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cipher: %w", err)
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, errors.New("initialisation vector does not match the block size")
+	}
+	if len(data)%block.BlockSize() != 0 {
+		return nil, errors.New("encrypted data is not a whole number of blocks")
+	}
+
+	out := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(out, data)
+	return out, nil
+}
+
+func decryptECB(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cipher: %w", err)
+	}
+	if len(data)%block.BlockSize() != 0 {
+		return nil, errors.New("encrypted data is not a whole number of blocks")
+	}
+
+	out := make([]byte, len(data))
+	for i := 0; i < len(data); i += block.BlockSize() {
+		block.Decrypt(out[i:], data[i:])
+	}
+	return out, nil
+}
@@ -0,0 +1,104 @@
+package xlsxreader
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readAllRows(t *testing.T, x *XlsxFile, sheet string) []Row {
+	var rows []Row
+	for row := range x.ReadRows(sheet) {
+		require.NoError(t, row.Error)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+var encryptedFileTests = []struct {
+	Name     string
+	Filename string
+}{
+	{Name: "Agile", Filename: "test/test-encrypted-agile.xlsx"},
+	{Name: "Standard", Filename: "test/test-encrypted-standard.xlsx"},
+}
+
+func TestOpeningEncryptedFiles(t *testing.T) {
+	plain, err := OpenFile("test/test-small.xlsx")
+	require.NoError(t, err)
+	defer plain.Close()
+
+	for _, test := range encryptedFileTests {
+		t.Run(test.Name, func(t *testing.T) {
+			e, err := OpenFileWithPassword(test.Filename, "password")
+			require.NoError(t, err)
+			defer e.Close()
+
+			require.Equal(t, plain.Sheets, e.Sheets)
+			require.Equal(t, readAllRows(t, &plain.XlsxFile, plain.Sheets[0]), readAllRows(t, &e.XlsxFile, e.Sheets[0]))
+		})
+	}
+}
+
+func TestOpeningEncryptedFilesWithIncorrectPassword(t *testing.T) {
+	for _, test := range encryptedFileTests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := OpenFileWithPassword(test.Filename, "wrong")
+			require.True(t, errors.Is(err, ErrIncorrectPassword))
+		})
+	}
+}
+
+func TestOpeningEncryptedFilesWithoutPassword(t *testing.T) {
+	_, err := OpenFile("test/test-encrypted-agile.xlsx")
+	require.True(t, errors.Is(err, ErrEncrypted))
+
+	data, err := os.ReadFile("test/test-encrypted-standard.xlsx")
+	require.NoError(t, err)
+	_, err = NewReader(data)
+	require.True(t, errors.Is(err, ErrEncrypted))
+}
+
+func TestOpeningUnencryptedFileWithPassword(t *testing.T) {
+	e, err := OpenFileWithPassword("test/test-small.xlsx", "password")
+	require.NoError(t, err)
+	require.NoError(t, e.Close())
+	require.NoError(t, e.Close())
+}
+
+var corruptEncryptionInfoTests = []struct {
+	Name string
+	Old  string
+	New  string
+}{
+	{Name: "Negative key size", Old: `keyBits="256"`, New: `keyBits="-25"`},
+	{Name: "Block size", Old: `blockSize="16"`, New: `blockSize="99"`},
+	{Name: "Salt size", Old: `saltSize="16"`, New: `saltSize="-1"`},
+	{Name: "Hash size", Old: `hashSize="64"`, New: `hashSize="-3"`},
+	{
+		Name: "Spin count",
+		Old:  `spinCount="100000" saltSize="16" blockSize="16" keyBits="256" hashSize="64" cipherAlgorithm="AES" cipherChaining="ChainingModeCBC" hashAlgorithm="SHA512" saltValue="09HrFanw6wfTbhPWxpE9zQ=="`,
+		New:  `spinCount="20000000" saltSize="16" blockSize="16" keyBits="256" hashSize="64" cipherAlgorithm="AES" cipherChaining="ChainingModeCBC" hashAlgorithm="SHA512" saltValue="09HrFanw6wfTbhPWxpE9zQ"`,
+	},
+}
+
+func TestOpeningEncryptedFilesWithCorruptEncryptionInfo(t *testing.T) {
+	data, err := os.ReadFile("test/test-encrypted-agile.xlsx")
+	require.NoError(t, err)
+
+	for _, test := range corruptEncryptionInfoTests {
+		t.Run(test.Name, func(t *testing.T) {
+			// The values are replaced in place, so that the streams of the file are unchanged
+			require.Equal(t, len(test.Old), len(test.New))
+			require.True(t, bytes.Contains(data, []byte(test.Old)))
+			corrupt := bytes.ReplaceAll(data, []byte(test.Old), []byte(test.New))
+
+			_, err := NewReaderWithPassword(corrupt, "password")
+			require.Error(t, err)
+			require.False(t, errors.Is(err, ErrIncorrectPassword))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
 	"sync"
 )
 
@@ -12,9 +13,23 @@ import (
 type XlsxFile struct {
 	Sheets []string
 
+	// SheetInfo describes each of the sheets, in the same order as Sheets.
+	SheetInfo []SheetInfo
+
+	// Date1904 is true if dates within the workbook are stored relative to 1904,
+	// rather than relative to 1900.
+	Date1904 bool
+
+	// DefinedNames holds the names defined within the workbook, including those built in
+	// to Excel and those hidden from users.
+	DefinedNames []DefinedName
+
+	files         []*zip.File
+	workbookName  string
 	sheetFiles    map[string]*zip.File
 	sharedStrings []string
 	dateStyles    map[int]bool
+	format        sheetFormat // Only set for workbooks whose sheets are not stored as XML
 
 	doneCh chan struct{} // doneCh serves as a signal to abort unfinished operations.
 }
@@ -58,8 +73,8 @@ func readFile(file *zip.File) ([]byte, error) {
 
 // GetSheetFileForSheetName returns the sheet file associated with the sheet name.
 // This is useful when you want to further process something out of the sheet, that this
-// library does not handle. For example this is useful when trying to read the hyperlinks
-// section of a sheet file; getting the sheet file enables you to read the XML directly.
+// library does not handle. For example this is useful when trying to read the conditional
+// formatting section of a sheet file; getting the sheet file enables you to read the XML directly.
 func (xl *XlsxFileCloser) GetSheetFileForSheetName(sheetName string) *zip.File {
 	sheetFile, _ := xl.sheetFiles[sheetName]
 	return sheetFile
@@ -71,16 +86,30 @@ func (xl *XlsxFileCloser) Close() error {
 		return nil
 	}
 	xl.once.Do(func() { close(xl.doneCh) })
+	if xl.zipReadCloser == nil {
+		// The file was decrypted into memory, so there is nothing further to close
+		return nil
+	}
 	return xl.zipReadCloser.Close()
 }
 
 // OpenFile takes the name of an XLSX file and returns a populated XlsxFile struct for it.
 // If the file cannot be found, or key parts of the files contents are missing, an error
 // is returned.
+// Binary (.xlsb), OpenDocument (.ods) and legacy Excel 97-2003 (.xls) workbooks are also
+// supported, with legacy workbooks being read into memory.
 // Note that the file must be Close()-d when you are finished with it.
 func OpenFile(filename string) (*XlsxFileCloser, error) {
 	zipFile, err := zip.OpenReader(filename)
 	if err != nil {
+		if data, readErr := os.ReadFile(filename); readErr == nil && isCompoundFile(data) {
+			// Legacy workbooks are read into memory, so there is no archive to close
+			x, err := newLegacyReader(data)
+			if err != nil {
+				return nil, fmt.Errorf("unable to open file reader: %w", err)
+			}
+			return &XlsxFileCloser{XlsxFile: *x}, nil
+		}
 		return nil, fmt.Errorf("unable to open file reader: %w", err)
 	}
 
@@ -120,6 +149,13 @@ func OpenReaderZip(rc *zip.ReadCloser) (*XlsxFileCloser, error) {
 func NewReader(xlsxBytes []byte) (*XlsxFile, error) {
 	r, err := zip.NewReader(bytes.NewReader(xlsxBytes), int64(len(xlsxBytes)))
 	if err != nil {
+		if isCompoundFile(xlsxBytes) {
+			x, err := newLegacyReader(xlsxBytes)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create new reader: %w", err)
+			}
+			return x, nil
+		}
 		return nil, fmt.Errorf("unable to create new reader: %w", err)
 	}
 
@@ -132,6 +168,42 @@ func NewReader(xlsxBytes []byte) (*XlsxFile, error) {
 	return &x, nil
 }
 
+// OpenFileWithPassword takes the name of an XLSX file which has been encrypted with a
+// password, and returns a populated XlsxFile struct for it. The file is decrypted into
+// memory. Files which have not been encrypted are opened as normal.
+// If the password is incorrect, ErrIncorrectPassword is returned.
+// Note that the file must be Close()-d when you are finished with it.
+func OpenFileWithPassword(filename string, password string) (*XlsxFileCloser, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file: %w", err)
+	}
+
+	x, err := NewReaderWithPassword(data, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return &XlsxFileCloser{XlsxFile: *x}, nil
+}
+
+// NewReaderWithPassword takes bytes of an Xlsx file which has been encrypted with a password,
+// and returns a populated XlsxFile struct for it. Files which have not been encrypted are
+// read as normal.
+// If the password is incorrect, ErrIncorrectPassword is returned.
+func NewReaderWithPassword(xlsxBytes []byte, password string) (*XlsxFile, error) {
+	if !isEncrypted(xlsxBytes) {
+		return NewReader(xlsxBytes)
+	}
+
+	decrypted, err := decryptPackage(xlsxBytes, password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt file: %w", err)
+	}
+
+	return NewReader(decrypted)
+}
+
 // NewReaderZip takes zip reader of Xlsx file and returns a populated XlsxFile struct for it.
 // If the file cannot be found, or key parts of the files contents are missing, an error
 // is returned.
@@ -146,23 +218,51 @@ func NewReaderZip(r *zip.Reader) (*XlsxFile, error) {
 }
 
 func (x *XlsxFile) init(zipReader *zip.Reader) error {
-	sharedStrings, err := getSharedStrings(zipReader.File)
+	if isOpenDocument(zipReader.File) {
+		return x.initOpenDocument(zipReader.File)
+	}
+	if _, err := getFileForName(zipReader.File, binaryWorkbookName); err == nil {
+		return x.initBinary(zipReader.File)
+	}
+
+	// The parts of the workbook are found through their relationships, which are named the
+	// same in both transitional and strict workbooks
+	workbookName := getWorkbookName(zipReader.File)
+	rels, err := getPartRelationships(zipReader.File, workbookName)
+	if err != nil {
+		return fmt.Errorf("unable to get workbook relationships: %w", err)
+	}
+
+	sharedStrings, err := getSharedStrings(zipReader.File, getRelatedPartName(rels, workbookName, "sharedStrings", ""))
 	if err != nil {
 		return fmt.Errorf("unable to get shared strings: %w", err)
 	}
 
-	sheets, sheetFiles, err := getWorksheets(zipReader.File)
+	wb, err := getWorkbook(zipReader.File, workbookName)
+	if err != nil {
+		return fmt.Errorf("unable to get workbook: %w", err)
+	}
+
+	sheetInfo, sheetFiles, err := getWorksheets(zipReader.File, wb, workbookName)
 	if err != nil {
 		return fmt.Errorf("unable to get worksheets: %w", err)
 	}
 
-	dateStyles, err := getDateFormatStyles(zipReader.File)
+	dateStyles, err := getDateFormatStyles(zipReader.File, getRelatedPartName(rels, workbookName, "styles", "xl/styles.xml"))
 	if err != nil {
 		return fmt.Errorf("unable to get date styles: %w", err)
 	}
 
+	x.files = zipReader.File
+	x.workbookName = workbookName
 	x.sharedStrings = sharedStrings
-	x.Sheets = sheets
+	x.Sheets = make([]string, len(sheetInfo))
+	for i, s := range sheetInfo {
+		x.Sheets[i] = s.Name
+	}
+	x.SheetInfo = sheetInfo
+	x.Date1904 = wb.Properties.Date1904
+	x.DefinedNames = getDefinedNames(wb)
 	x.sheetFiles = *sheetFiles
 	x.dateStyles = *dateStyles
 	x.doneCh = make(chan struct{})
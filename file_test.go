@@ -44,12 +44,68 @@ func TestHandlingSpuriousWorkbookLinks(t *testing.T) {
 	defer f.Close()
 }
 
+func TestOpeningStrictXlsxFile(t *testing.T) {
+	f, err := OpenFile("./test/test-strict.xlsx")
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.Equal(t, []SheetInfo{
+		{Name: "Strict", ID: 1, State: SheetVisible, Kind: KindWorksheet},
+		{Name: "Hidden", ID: 2, State: SheetHidden, Kind: KindWorksheet, Active: true},
+	}, f.SheetInfo)
+
+	var rows []Row
+	for row := range f.ReadRowsWithOptions("Strict", Options{Hyperlinks: true}) {
+		require.NoError(t, row.Error)
+		rows = append(rows, row)
+	}
+
+	require.Equal(t, []Row{
+		{Index: 1, Cells: []Cell{
+			{Column: "A", Row: 1, Value: "date", Type: TypeString},
+			{Column: "B", Row: 1, Value: "2019-01-24T06:00:00Z", Type: TypeDateTime, serial: "43489.25"},
+			{Column: "C", Row: 1, Value: "2019-01-24", Type: TypeDateTime, serial: "43489"},
+			{Column: "D", Row: 1, Value: "1899-12-30T12:30:15Z", Type: TypeDateTime, serial: "0.5210069444444444"},
+		}},
+		{Index: 2, Cells: []Cell{
+			{Column: "A", Row: 2, Value: "42", Type: TypeNumerical},
+			{Column: "B", Row: 2, Value: "2019-01-24T06:00:00Z", Type: TypeDateTime, serial: "43489.25"},
+			{Column: "C", Row: 2, Value: "1", Type: TypeBoolean},
+			{Column: "D", Row: 2, Value: "link", Type: TypeString, Hyperlink: &Hyperlink{
+				Ref: "D2", URL: "https://example.com/",
+			}},
+		}},
+	}, rows)
+}
+
 func TestOpeningXlsxFile(t *testing.T) {
 	f, err := OpenFile("./test/test-small.xlsx")
 	require.NoError(t, err)
 	defer f.Close()
 
 	require.Equal(t, []string{"datarefinery_groundtruth_400000"}, f.Sheets)
+	require.False(t, f.Date1904)
+}
+
+func TestOpeningXlsxFileWith1904Dates(t *testing.T) {
+	f, err := OpenFile("./test/test-date1904.xlsx")
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.True(t, f.Date1904)
+
+	var rows []Row
+	for row := range f.ReadRows("Dates") {
+		rows = append(rows, row)
+	}
+
+	require.Equal(t, []Row{
+		{Index: 1, Cells: []Cell{
+			{Column: "A", Row: 1, Value: "1904-01-01", Type: TypeDateTime, serial: "0", date1904: true},
+			{Column: "B", Row: 1, Value: "2019-01-24T06:00:00Z", Type: TypeDateTime, serial: "42027.25", date1904: true},
+			{Column: "C", Row: 1, Value: "42027", Type: TypeNumerical},
+		}},
+	}, rows)
 }
 
 func TestOpeningZipReadCloser(t *testing.T) {
@@ -0,0 +1,35 @@
+package xlsxreader
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotSupported indicates that a feature is not supported for the format of a workbook,
+// such as reading the merged cells of a binary workbook.
+var ErrNotSupported = errors.New("not supported for this format of workbook")
+
+// notSupported gives the error returned when reading a feature of the workbook which is not
+// supported for its format.
+func (x *XlsxFile) notSupported(feature string) error {
+	return fmt.Errorf("unable to read %s of %s workbook: %w", feature, x.format.name(), ErrNotSupported)
+}
+
+// sheetFormat reads the sheets of workbooks which are not stored as SpreadsheetML XML.
+// Only the rows of such sheets can be read.
+type sheetFormat interface {
+	// name gives the name of the format, E.G   xlsb
+	name() string
+	// openRows opens the named sheet of the workbook for reading its rows.
+	openRows(x *XlsxFile, sheet string) (rowSource, error)
+}
+
+// rowSource reads the rows of a sheet, in order.
+type rowSource interface {
+	// next reads the next row of the sheet. It returns io.EOF once there are no more rows.
+	next() (Row, error)
+	// dimension gives the range of used cells declared by the sheet, or nil if it is unknown.
+	dimension() *cellRange
+	io.Closer
+}
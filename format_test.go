@@ -0,0 +1,158 @@
+package xlsxreader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// otherFormatFiles hold the same sheets and values, stored in each of the formats other
+// than SpreadsheetML XML.
+var otherFormatFiles = []string{
+	"test/test-binary.xlsb",
+	"test/test-legacy.xls",
+	"test/test-opendocument.ods",
+}
+
+// otherFormatRows are the rows of the Values sheet of each of otherFormatFiles. Only the
+// cells and visibility of each row are given, as not every format records the others.
+var otherFormatRows = []Row{
+	{Index: 1, Cells: []Cell{
+		{Column: "A", Row: 1, Value: "name", Type: TypeString},
+		{Column: "B", Row: 1, Value: "value", Type: TypeString},
+		{Column: "C", Row: 1, Value: "inline", Type: TypeString},
+		{Column: "D", Row: 1, Value: "日本語", Type: TypeString},
+	}},
+	{Index: 2, Cells: []Cell{
+		{Column: "A", Row: 2, Value: "42", Type: TypeNumerical},
+		{Column: "B", Row: 2, Value: "0.5", Type: TypeNumerical},
+		{Column: "C", Row: 2, Value: "1.23", Type: TypeNumerical},
+	}},
+	{Index: 3, Hidden: true, Cells: []Cell{
+		{Column: "A", Row: 3, Value: "1", Type: TypeBoolean},
+		{Column: "B", Row: 3, Value: "#DIV/0!", Type: TypeError},
+	}},
+	{Index: 4, Cells: []Cell{
+		{Column: "A", Row: 4, Value: "2019-01-24T06:00:00Z", Type: TypeDateTime, serial: "43489.25"},
+		{Column: "B", Row: 4, Value: "2019-01-24", Type: TypeDateTime, serial: "43489"},
+	}},
+	{Index: 6, Cells: []Cell{
+		{Column: "A", Row: 6, Value: "3", Type: TypeNumerical},
+		{Column: "B", Row: 6, Value: "calculated", Type: TypeString},
+		{Column: "C", Row: 6, Value: "0", Type: TypeBoolean},
+		{Column: "D", Row: 6, Value: "rich", Type: TypeString},
+	}},
+}
+
+func TestOpeningOtherFormats(t *testing.T) {
+	for _, filename := range otherFormatFiles {
+		t.Run(filename, func(t *testing.T) {
+			e, err := OpenFile(filename)
+			require.NoError(t, err)
+			defer e.Close()
+
+			require.Equal(t, []string{"Values", "Empty"}, e.Sheets)
+			require.Equal(t, []SheetInfo{
+				{Name: "Values", ID: 1, State: SheetVisible, Kind: KindWorksheet},
+				{Name: "Empty", ID: 2, State: SheetHidden, Kind: KindWorksheet, Active: true},
+			}, e.SheetInfo)
+			require.False(t, e.Date1904)
+		})
+	}
+}
+
+func TestReadingOtherFormats(t *testing.T) {
+	for _, filename := range otherFormatFiles {
+		t.Run(filename, func(t *testing.T) {
+			e, err := OpenFile(filename)
+			require.NoError(t, err)
+			defer e.Close()
+
+			var rows []Row
+			for row := range e.ReadRows("Values") {
+				require.NoError(t, row.Error)
+				rows = append(rows, Row{Index: row.Index, Hidden: row.Hidden, Cells: row.Cells})
+			}
+			require.Equal(t, otherFormatRows, rows)
+
+			rows = nil
+			for row := range e.ReadRows("Empty") {
+				rows = append(rows, row)
+			}
+			require.Empty(t, rows)
+		})
+	}
+}
+
+func TestUnsupportedFeaturesOfOtherFormats(t *testing.T) {
+	for _, filename := range otherFormatFiles {
+		t.Run(filename, func(t *testing.T) {
+			e, err := OpenFile(filename)
+			require.NoError(t, err)
+			defer e.Close()
+
+			_, err = e.MergedCells("Values")
+			require.True(t, errors.Is(err, ErrNotSupported))
+
+			_, err = e.MergedCells("NonExistent")
+			require.Error(t, err)
+			require.False(t, errors.Is(err, ErrNotSupported))
+
+			_, err = e.Comments("Values")
+			require.True(t, errors.Is(err, ErrNotSupported))
+
+			_, err = e.Hyperlinks("Values")
+			require.True(t, errors.Is(err, ErrNotSupported))
+
+			_, err = e.Tables()
+			require.True(t, errors.Is(err, ErrNotSupported))
+
+			for row := range e.ReadTable("Table1") {
+				require.True(t, errors.Is(row.Error, ErrNotSupported))
+			}
+
+			for _, opts := range []Options{{Comments: true}, {Hyperlinks: true}, {SkipHiddenColumns: true}} {
+				var rows []Row
+				for row := range e.ReadRowsWithOptions("Values", opts) {
+					rows = append(rows, row)
+				}
+				require.Len(t, rows, 1)
+				require.True(t, errors.Is(rows[0].Error, ErrNotSupported))
+			}
+
+			for row := range e.ReadRows("NonExistent") {
+				require.Error(t, row.Error)
+				require.False(t, errors.Is(row.Error, ErrNotSupported))
+			}
+		})
+	}
+}
+
+func TestReadingRowAttributesOfOtherFormats(t *testing.T) {
+	// Unlike OpenDocument spreadsheets, binary and legacy workbooks record the height and
+	// outline level of each row, along with the range of used cells
+	for _, filename := range otherFormatFiles[:2] {
+		t.Run(filename, func(t *testing.T) {
+			e, err := OpenFile(filename)
+			require.NoError(t, err)
+			defer e.Close()
+
+			rows := readAllRows(t, &e.XlsxFile, "Values")
+			var heights []float64
+			for _, row := range rows {
+				heights = append(heights, row.Height)
+			}
+			require.Equal(t, []float64{15, 15, 30, 15, 15}, heights)
+			require.True(t, rows[2].CustomHeight)
+			require.Equal(t, 1, rows[2].OutlineLevel)
+
+			var widths []int
+			for row := range e.ReadRowsWithOptions("Values", Options{Dense: true}) {
+				require.NoError(t, row.Error)
+				widths = append(widths, len(row.Cells))
+			}
+			require.Equal(t, []int{4, 4, 4, 4, 4, 4}, widths)
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package xlsxreader
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FormulaType defines the kind of formula used to calculate a cell's value.
+type FormulaType string
+
+const (
+	// FormulaNormal is for a formula belonging to a single cell
+	FormulaNormal FormulaType = "normal"
+	// FormulaShared is for a formula shared by a range of cells, each adjusted relative to its position
+	FormulaShared FormulaType = "shared"
+	// FormulaArray is for an array formula, populating every cell within its range
+	FormulaArray FormulaType = "array"
+	// FormulaDataTable is for a what-if data table, populating every cell within its range
+	FormulaDataTable FormulaType = "dataTable"
+)
+
+// rawFormula represents the raw XML element for parsing a cell's formula.
+type rawFormula struct {
+	Type        string `xml:"t,attr,omitempty"`
+	Ref         string `xml:"ref,attr,omitempty"`
+	SharedIndex string `xml:"si,attr,omitempty"`
+	Text        string `xml:",chardata"`
+}
+
+func (rf *rawFormula) unmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "t":
+			rf.Type = attr.Value
+		case "ref":
+			rf.Ref = attr.Value
+		case "si":
+			rf.SharedIndex = attr.Value
+		}
+	}
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		switch el := tok.(type) {
+		case xml.CharData:
+			rf.Text += string(el)
+		case xml.EndElement:
+			if el == start.End() {
+				return nil
+			}
+		}
+	}
+}
+
+// sharedFormula holds the text of a shared formula, along with the position of the cell
+// it was defined against.
+type sharedFormula struct {
+	Text   string
+	Column int
+	Row    int
+}
+
+// sharedFormulas is a lookup of the shared formulas seen so far whilst reading a sheet,
+// keyed by their shared index. Shared formulas are only written out in full on the first
+// cell of their range, so this must be populated as rows are read.
+type sharedFormulas map[string]sharedFormula
+
+// getCellFormula interrogates a raw cell to get the text and type of its formula, if it has one.
+// Cells belonging to a shared formula have the formula of the defining cell, adjusted
+// relative to their own position.
+func (sf sharedFormulas) getCellFormula(r rawCell) (string, FormulaType, error) {
+	if r.Formula == nil {
+		return "", "", nil
+	}
+
+	switch r.Formula.Type {
+	case "array":
+		return r.Formula.Text, FormulaArray, nil
+	case "dataTable":
+		return r.Formula.Text, FormulaDataTable, nil
+	case "shared":
+	default:
+		return r.Formula.Text, FormulaNormal, nil
+	}
+
+	column, row, err := splitCellReference(r.Reference)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to get shared formula for cell %s: %w", r.Reference, err)
+	}
+
+	if r.Formula.Ref != "" {
+		sf[r.Formula.SharedIndex] = sharedFormula{Text: r.Formula.Text, Column: column, Row: row}
+		return r.Formula.Text, FormulaShared, nil
+	}
+
+	master, ok := sf[r.Formula.SharedIndex]
+	if !ok {
+		return "", "", fmt.Errorf("unable to find shared formula %s for cell %s", r.Formula.SharedIndex, r.Reference)
+	}
+
+	return shiftFormula(master.Text, row-master.Row, column-master.Column), FormulaShared, nil
+}
+
+var (
+	// cellReferencePattern matches references to single cells, e.g. A1, $A$1
+	cellReferencePattern = regexp.MustCompile(`^(\$?)([A-Z]{1,3})(\$?)([0-9]+)`)
+	// columnRangePattern matches references to whole columns, e.g. A:C, $A:$C
+	columnRangePattern = regexp.MustCompile(`^(\$?)([A-Z]{1,3}):(\$?)([A-Z]{1,3})`)
+	// rowRangePattern matches references to whole rows, e.g. 1:3, $1:$3
+	rowRangePattern = regexp.MustCompile(`^(\$?)([0-9]+):(\$?)([0-9]+)`)
+)
+
+// shiftFormula moves every relative reference within a formula by the given number of rows
+// and columns, as Excel does when a formula is copied from one cell to another.
+// Absolute references ($A$1), string literals, quoted sheet names and structured
+// references are left untouched. References shifted beyond the edge of the sheet become #REF!.
+func shiftFormula(formula string, rows, columns int) string {
+	if rows == 0 && columns == 0 {
+		return formula
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(formula); {
+		c := formula[i]
+
+		switch {
+		case c == '"' || c == '\'':
+			end := skipQuoted(formula, i)
+			sb.WriteString(formula[i:end])
+			i = end
+			continue
+		case c == '[':
+			end := skipBracketed(formula, i)
+			sb.WriteString(formula[i:end])
+			i = end
+			continue
+		case !isIdentifierByte(c) && c != '$':
+			sb.WriteByte(c)
+			i++
+			continue
+		}
+
+		rest := formula[i:]
+		if m := cellReferencePattern.FindStringSubmatch(rest); m != nil && isReferenceEnd(rest, len(m[0])) {
+			sb.WriteString(joinReference("", shiftColumn(m[1], m[2], columns), shiftRow(m[3], m[4], rows)))
+			i += len(m[0])
+			continue
+		}
+		if m := columnRangePattern.FindStringSubmatch(rest); m != nil && isReferenceEnd(rest, len(m[0])) {
+			sb.WriteString(joinReference(":", shiftColumn(m[1], m[2], columns), shiftColumn(m[3], m[4], columns)))
+			i += len(m[0])
+			continue
+		}
+		if m := rowRangePattern.FindStringSubmatch(rest); m != nil && isReferenceEnd(rest, len(m[0])) {
+			sb.WriteString(joinReference(":", shiftRow(m[1], m[2], rows), shiftRow(m[3], m[4], rows)))
+			i += len(m[0])
+			continue
+		}
+
+		// not a reference, so copy the whole name or number verbatim
+		end := i + 1
+		for end < len(formula) && isIdentifierByte(formula[end]) {
+			end++
+		}
+		sb.WriteString(formula[i:end])
+		i = end
+	}
+
+	return sb.String()
+}
+
+// The size of a sheet, beyond which references no longer point to a cell.
+const (
+	maxColumns       = 16384 // Up to column XFD
+	maxColumnLetters = 3
+	maxRows          = 1048576
+)
+
+// invalidReference is the text Excel substitutes for a reference which no longer points
+// to a cell within the sheet.
+const invalidReference = "#REF!"
+
+// joinReference joins the shifted parts of a reference, collapsing the whole reference to
+// #REF! if any part of it became invalid.
+func joinReference(sep, first, second string) string {
+	if first == invalidReference || second == invalidReference {
+		return invalidReference
+	}
+	return first + sep + second
+}
+
+// shiftColumn moves a column name by an offset, unless it is marked as absolute.
+func shiftColumn(absolute, name string, offset int) string {
+	if absolute != "" {
+		return absolute + name
+	}
+
+	index := asIndex(name) + offset
+	if index < 0 || index >= maxColumns {
+		return invalidReference
+	}
+	return columnName(index)
+}
+
+// shiftRow moves a row number by an offset, unless it is marked as absolute.
+func shiftRow(absolute, number string, offset int) string {
+	if absolute != "" {
+		return absolute + number
+	}
+
+	row, _ := strconv.Atoi(number)
+	if row+offset < 1 || row+offset > maxRows {
+		return invalidReference
+	}
+	return strconv.Itoa(row + offset)
+}
+
+// isReferenceEnd reports whether a reference matched at the start of s is a complete
+// token, rather than the beginning of a function, sheet or defined name.
+func isReferenceEnd(s string, end int) bool {
+	if end == len(s) {
+		return true
+	}
+	c := s[end]
+	return !isIdentifierByte(c) && c != '(' && c != '!' && c != '$'
+}
+
+// isIdentifierByte reports whether a byte may form part of a name, number or reference in a formula.
+func isIdentifierByte(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+		c == '_' || c == '.' || c == '\\' || c >= 0x80
+}
+
+// skipQuoted returns the index just beyond the string literal or quoted sheet name starting at i.
+// Quote characters are escaped by doubling them.
+func skipQuoted(s string, i int) int {
+	quote := s[i]
+	for j := i + 1; j < len(s); j++ {
+		if s[j] != quote {
+			continue
+		}
+		if j+1 < len(s) && s[j+1] == quote {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return len(s)
+}
+
+// skipBracketed returns the index just beyond the (possibly nested) square brackets starting
+// at i, as used by structured table references and external workbook references.
+func skipBracketed(s string, i int) int {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return j + 1
+			}
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,117 @@
+package xlsxreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var shiftFormulaTests = []struct {
+	Formula  string
+	Rows     int
+	Columns  int
+	Expected string
+}{
+	{"A1*2", 0, 0, "A1*2"},
+	{"A1*2", 1, 0, "A2*2"},
+	{"A1*2", 0, 2, "C1*2"},
+	{"$A$1+A$1+$A1", 3, 3, "$A$1+D$1+$A4"},
+	{"SUM(A1:B2)", 1, 1, "SUM(B2:C3)"},
+	{"SUM(A:B)+SUM(1:2)", 1, 1, "SUM(B:C)+SUM(2:3)"},
+	{"LOG10(A1)", 1, 0, "LOG10(A2)"},
+	{`"A1"&A1`, 1, 0, `"A1"&A2`},
+	{`"say ""A1"""&A1`, 1, 0, `"say ""A1"""&A2`},
+	{"Sheet2!A1+'Sheet 3'!B2", 1, 0, "Sheet2!A2+'Sheet 3'!B3"},
+	{"ABC1!A1", 1, 0, "ABC1!A2"},
+	{"Table1[[#This Row],[A1]]+A1", 1, 0, "Table1[[#This Row],[A1]]+A2"},
+	{"Q1_Sales*A1", 1, 0, "Q1_Sales*A2"},
+	{"1.5E+10*A1", 1, 0, "1.5E+10*A2"},
+	{"A2-B1", -1, 0, "A1-#REF!"},
+	{"B1", 0, -2, "#REF!"},
+	{"XFD1", 0, 1, "#REF!"},
+	{"A1048576", 1, 0, "#REF!"},
+	{"SUM(XFC:XFD)", 0, 1, "SUM(#REF!)"},
+	{"SUM(1048575:1048576)", 1, 0, "SUM(#REF!)"},
+}
+
+func TestShiftingFormulas(t *testing.T) {
+	for _, test := range shiftFormulaTests {
+		t.Run(test.Formula, func(t *testing.T) {
+			actual := shiftFormula(test.Formula, test.Rows, test.Columns)
+
+			require.Equal(t, test.Expected, actual)
+		})
+	}
+}
+
+func TestGettingCellFormulas(t *testing.T) {
+	formulas := sharedFormulas{}
+
+	formula, typ, err := formulas.getCellFormula(rawCell{Reference: "A1"})
+	require.NoError(t, err)
+	require.Equal(t, "", formula)
+	require.Equal(t, FormulaType(""), typ)
+
+	formula, typ, err = formulas.getCellFormula(rawCell{Reference: "B2", Formula: &rawFormula{Text: "A1+1"}})
+	require.NoError(t, err)
+	require.Equal(t, "A1+1", formula)
+	require.Equal(t, FormulaNormal, typ)
+
+	formula, typ, err = formulas.getCellFormula(rawCell{Reference: "B2", Formula: &rawFormula{Type: "shared", Ref: "B2:C3", SharedIndex: "4", Text: "A1+1"}})
+	require.NoError(t, err)
+	require.Equal(t, "A1+1", formula)
+	require.Equal(t, FormulaShared, typ)
+
+	formula, typ, err = formulas.getCellFormula(rawCell{Reference: "C3", Formula: &rawFormula{Type: "shared", SharedIndex: "4"}})
+	require.NoError(t, err)
+	require.Equal(t, "B2+1", formula)
+	require.Equal(t, FormulaShared, typ)
+
+	_, _, err = formulas.getCellFormula(rawCell{Reference: "C3", Formula: &rawFormula{Type: "shared", SharedIndex: "5"}})
+	require.EqualError(t, err, "unable to find shared formula 5 for cell C3")
+}
+
+func TestReadingSharedFormulaWithoutValue(t *testing.T) {
+	// Generators commonly write shared formulas without caching the value of their first cell
+	value := "2"
+	formulas := sharedFormulas{}
+	cells, err := testFile.parseRawCells([]rawCell{
+		{Reference: "A1", Formula: &rawFormula{Type: "shared", Ref: "A1:A2", SharedIndex: "0", Text: "B1*2"}},
+	}, 1, formulas)
+	require.NoError(t, err)
+	require.Empty(t, cells)
+
+	cells, err = testFile.parseRawCells([]rawCell{
+		{Reference: "A2", Value: &value, Formula: &rawFormula{Type: "shared", SharedIndex: "0"}},
+	}, 2, formulas)
+	require.NoError(t, err)
+	require.Equal(t, []Cell{
+		{Column: "A", Row: 2, Value: "2", Type: TypeNumerical, Formula: "B2*2", FormulaType: FormulaShared},
+	}, cells)
+}
+
+func TestReadingFormulas(t *testing.T) {
+	e, err := OpenFile("test/test-formulas.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var cells []Cell
+	for row := range e.ReadRows("Formulas") {
+		require.NoError(t, row.Error)
+		cells = append(cells, row.Cells...)
+	}
+
+	require.Equal(t, []Cell{
+		{Column: "A", Row: 1, Value: "1", Type: TypeNumerical},
+		{Column: "B", Row: 1, Value: "12", Type: TypeNumerical, Formula: "A1*2+$D$1", FormulaType: FormulaShared},
+		{Column: "C", Row: 1, Value: "6", Type: TypeNumerical, Formula: "SUM(A1:A3)", FormulaType: FormulaNormal},
+		{Column: "D", Row: 1, Value: "10", Type: TypeNumerical},
+		{Column: "A", Row: 2, Value: "2", Type: TypeNumerical},
+		{Column: "B", Row: 2, Value: "14", Type: TypeNumerical, Formula: "A2*2+$D$1", FormulaType: FormulaShared},
+		{Column: "C", Row: 2, Value: "A12", Type: TypeString, Formula: `"A1"&A2`, FormulaType: FormulaNormal},
+		{Column: "A", Row: 3, Value: "3", Type: TypeNumerical},
+		{Column: "B", Row: 3, Value: "16", Type: TypeNumerical, Formula: "A3*2+$D$1", FormulaType: FormulaShared},
+		{Column: "C", Row: 3, Value: "4", Type: TypeNumerical, Formula: "A2:A3*2", FormulaType: FormulaArray, FormulaRange: "C3:C4"},
+		{Column: "C", Row: 4, Value: "6", Type: TypeNumerical},
+	}, cells)
+}
@@ -2,6 +2,11 @@ module github.com/thedatashed/xlsxreader
 
 retract v1.2.7
 
-go 1.15
+go 1.23
 
 require github.com/stretchr/testify v1.3.0
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
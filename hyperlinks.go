@@ -0,0 +1,126 @@
+package xlsxreader
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+// Hyperlink represents a hyperlink attached to a cell, or range of cells.
+// A hyperlink either points to an external resource, in which case URL is set, or to a
+// location within the workbook, in which case Location is set.
+type Hyperlink struct {
+	Ref      string // The cell or range of cells the hyperlink is attached to, E.G   A1, A1:B2
+	URL      string // E.G   https://example.com, mailto:someone@example.com
+	Location string // E.G   Sheet2!A1, or the name of a defined name
+	Display  string // The text displayed for the hyperlink, if it differs from the cell value
+	Tooltip  string
+}
+
+// Hyperlinks returns the hyperlinks within a sheet, with the URLs of external hyperlinks
+// resolved through the sheet's relationships.
+func (x *XlsxFile) Hyperlinks(sheet string) ([]Hyperlink, error) {
+	if x.format != nil {
+		return nil, x.notSupported("hyperlinks")
+	}
+
+	rels, err := x.getSheetRelationships(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read hyperlinks: %w", err)
+	}
+
+	var links []Hyperlink
+
+	err = x.scanSheet(sheet, map[string]elementHandler{"hyperlinks/hyperlink": hyperlinkHandler(rels, &links)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read hyperlinks: %w", err)
+	}
+
+	return links, nil
+}
+
+// hyperlinkHandler returns an elementHandler which appends each hyperlink element to links,
+// resolving the URLs of external hyperlinks through the sheet's relationships.
+func hyperlinkHandler(rels []relationship, links *[]Hyperlink) elementHandler {
+	return func(d *xml.Decoder, start xml.StartElement) error {
+		var link Hyperlink
+		for _, attr := range start.Attr {
+			switch attr.Name.Local {
+			case "ref":
+				link.Ref = attr.Value
+			case "location":
+				link.Location = attr.Value
+			case "display":
+				link.Display = attr.Value
+			case "tooltip":
+				link.Tooltip = attr.Value
+			case "id":
+				rel, ok := getRelationship(rels, attr.Value)
+				if !ok {
+					return fmt.Errorf("unable to find relationship %s for hyperlink", attr.Value)
+				}
+				link.URL = rel.Target
+			}
+		}
+
+		*links = append(*links, link)
+		return d.Skip()
+	}
+}
+
+// hyperlinkLookup finds the hyperlink attached to a cell.
+type hyperlinkLookup struct {
+	cells  map[string]*Hyperlink // Hyperlinks attached to a single cell, keyed by reference
+	ranges []hyperlinkRange      // Hyperlinks attached to a range of cells
+}
+
+type hyperlinkRange struct {
+	cellRange
+	link *Hyperlink
+}
+
+// newHyperlinkLookup creates a hyperlinkLookup for the given hyperlinks.
+// Where hyperlinks overlap, the first takes precedence.
+func newHyperlinkLookup(links []Hyperlink) (*hyperlinkLookup, error) {
+	l := &hyperlinkLookup{cells: map[string]*Hyperlink{}}
+
+	for i := range links {
+		link := &links[i]
+
+		r, err := parseCellRange(link.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse hyperlink: %w", err)
+		}
+
+		if r.FirstColumn != r.LastColumn || r.FirstRow != r.LastRow {
+			l.ranges = append(l.ranges, hyperlinkRange{cellRange: r, link: link})
+			continue
+		}
+
+		ref := columnName(r.FirstColumn) + strconv.Itoa(r.FirstRow)
+		if _, ok := l.cells[ref]; !ok {
+			l.cells[ref] = link
+		}
+	}
+
+	return l, nil
+}
+
+// attach sets the Hyperlink field of each cell within the row that has a hyperlink.
+func (l *hyperlinkLookup) attach(row Row) Row {
+	for i, cell := range row.Cells {
+		if link, ok := l.cells[cell.Reference()]; ok {
+			row.Cells[i].Hyperlink = link
+			continue
+		}
+
+		for _, r := range l.ranges {
+			if r.contains(cell.ColumnIndex(), cell.Row) {
+				row.Cells[i].Hyperlink = r.link
+				break
+			}
+		}
+	}
+
+	return row
+}
@@ -0,0 +1,74 @@
+package xlsxreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGettingHyperlinks(t *testing.T) {
+	e, err := OpenFile("test/test-hyperlinks.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	links, err := e.Hyperlinks("Catalogue")
+	require.NoError(t, err)
+	require.Equal(t, []Hyperlink{
+		{Ref: "B2", URL: "https://example.com/widget.png", Tooltip: "Widget image"},
+		{Ref: "B3", URL: "https://example.com/gadget.png", Display: "Gadget"},
+		{Ref: "A2:A3", Location: "Details!A1"},
+	}, links)
+
+	links, err = e.Hyperlinks("Details")
+	require.NoError(t, err)
+	require.Empty(t, links)
+}
+
+func TestGettingHyperlinksFromExcelFile(t *testing.T) {
+	e, err := OpenFile("test/test-deleted-sheet.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var links []Hyperlink
+	for _, sheet := range e.Sheets {
+		if e.sheetFiles[sheet].Name == "xl/worksheets/sheet1.xml" {
+			links, err = e.Hyperlinks(sheet)
+			require.NoError(t, err)
+		}
+	}
+
+	require.Len(t, links, 13)
+	require.Equal(t, Hyperlink{Ref: "A2", URL: "mailto:junie@example.com"}, links[0])
+}
+
+func TestReadingRowsWithHyperlinks(t *testing.T) {
+	e, err := OpenFile("test/test-hyperlinks.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var rows []Row
+	for row := range e.ReadRowsWithOptions("Catalogue", Options{Hyperlinks: true}) {
+		require.NoError(t, row.Error)
+		rows = append(rows, row)
+	}
+	require.Len(t, rows, 3)
+
+	require.Nil(t, rows[0].Cells[0].Hyperlink)
+	require.Nil(t, rows[0].Cells[1].Hyperlink)
+	require.Equal(t, "Details!A1", rows[1].Cells[0].Hyperlink.Location)
+	require.Equal(t, "https://example.com/widget.png", rows[1].Cells[1].Hyperlink.URL)
+	require.Equal(t, "Details!A1", rows[2].Cells[0].Hyperlink.Location)
+	require.Equal(t, "https://example.com/gadget.png", rows[2].Cells[1].Hyperlink.URL)
+}
+
+func TestReadingRowsWithoutHyperlinks(t *testing.T) {
+	e, err := OpenFile("test/test-hyperlinks.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	for row := range e.ReadRows("Catalogue") {
+		for _, cell := range row.Cells {
+			require.Nil(t, cell.Hyperlink)
+		}
+	}
+}
@@ -0,0 +1,430 @@
+package xlsxreader
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// RowIterator provides a pull based alternative to ReadRows, reading the rows of a worksheet
+// synchronously on the caller's goroutine.
+//
+// Rows are read by calling Next() until it returns false, then checking Err(). As with
+// ReadRows, errors encountered whilst interpreting the values of a single row are reported
+// on that row's Error field, whereas Err() reports the error that stopped iteration, if any.
+//
+// The iterator should be Close()-d if it is abandoned before Next() returns false.
+type RowIterator struct {
+	x       *XlsxFile
+	ctx     context.Context
+	file    io.Closer
+	decoder *xml.Decoder
+	source  rowSource // Only set for workbooks whose sheets are not stored as XML
+	row     Row
+	err     error
+
+	opts      Options
+	formulas  sharedFormulas
+	merges    *mergeFill       // Only set when filling merged cells
+	links     *hyperlinkLookup // Only set when reading with hyperlinks
+	comments  map[string]Comment
+	hidden    []ColumnInfo // Only set when skipping hidden columns
+	filter    *cellRange   // Only set when skipping filtered rows of a filtered sheet
+	dimension *cellRange   // The range of used cells, as declared by the sheet
+	queue     []Row        // Rows which have been read, but are yet to be returned by Next()
+	lastIndex int          // The index of the last row returned by Next()
+	lastRead  int          // The index of the last row read from the sheet
+	skipped   []int        // The indices of rows excluded by the options, when reading densely
+	corrupt   bool         // Whether a row could not be decoded, leaving the rest unreadable
+}
+
+// Rows returns a RowIterator over the rows of the named worksheet.
+// If the sheet cannot be opened, the first call to Next() will return false, and the
+// error will be available from Err().
+func (x *XlsxFile) Rows(sheet string) *RowIterator {
+	return x.newRowIterator(context.Background(), sheet, Options{})
+}
+
+// RowsWithOptions behaves like Rows, but with the rows read according to the options.
+func (x *XlsxFile) RowsWithOptions(sheet string, opts Options) *RowIterator {
+	return x.newRowIterator(context.Background(), sheet, opts)
+}
+
+// All returns an iterator over the rows of the named worksheet, for use with range-over-func:
+//
+//	for row, err := range xl.All("Sheet1") {
+//		...
+//	}
+//
+// The error yielded alongside each row is the same as the row's Error field. If reading
+// the sheet fails entirely, a final row is yielded carrying the error. Breaking out of the
+// loop early releases the underlying sheet file.
+func (x *XlsxFile) All(sheet string) iter.Seq2[Row, error] {
+	return x.all(context.Background(), sheet, Options{})
+}
+
+// AllWithOptions behaves like All, but with the rows read according to the options.
+func (x *XlsxFile) AllWithOptions(sheet string, opts Options) iter.Seq2[Row, error] {
+	return x.all(context.Background(), sheet, opts)
+}
+
+// all returns an iterator over the rows of a worksheet, which stops once the context is done.
+func (x *XlsxFile) all(ctx context.Context, sheet string, opts Options) iter.Seq2[Row, error] {
+	return func(yield func(Row, error) bool) {
+		it := x.newRowIterator(ctx, sheet, opts)
+		defer it.Close()
+
+		for it.Next() {
+			row := it.Row()
+			if !yield(row, row.Error) {
+				return
+			}
+		}
+
+		if err := it.Err(); err != nil {
+			yield(Row{Error: err}, err)
+		}
+	}
+}
+
+// newRowIterator creates a RowIterator which reads rows according to the options, and
+// additionally stops once the context is done.
+func (x *XlsxFile) newRowIterator(ctx context.Context, sheet string, opts Options) *RowIterator {
+	it := &RowIterator{x: x, ctx: ctx, opts: opts, formulas: sharedFormulas{}}
+
+	if x.format != nil && opts.Comments {
+		it.err = x.notSupported("comments")
+		return it
+	}
+	if x.format != nil && opts.Hyperlinks {
+		it.err = x.notSupported("hyperlinks")
+		return it
+	}
+	if x.format != nil && opts.SkipHiddenColumns {
+		it.err = x.notSupported("hidden columns")
+		return it
+	}
+
+	if err := it.scanSheet(sheet); err != nil {
+		it.err = err
+		return it
+	}
+
+	if opts.Comments {
+		comments, err := x.Comments(sheet)
+		if err != nil {
+			it.err = err
+			return it
+		}
+		it.comments = comments
+	}
+
+	if x.format != nil {
+		source, err := x.format.openRows(x, sheet)
+		if err != nil {
+			it.err = err
+			return it
+		}
+
+		it.file = source
+		it.source = source
+		return it
+	}
+
+	file, err := x.openSheetFile(sheet)
+	if err != nil {
+		it.err = err
+		return it
+	}
+
+	it.file = file
+	it.decoder = xml.NewDecoder(file)
+	return it
+}
+
+// scanSheet reads the merged cells, hyperlinks and auto filter of a sheet, as needed by the
+// iterator's options. These are all read in a single pass through the sheet, before any of
+// its rows are read.
+func (it *RowIterator) scanSheet(sheet string) error {
+	handlers := map[string]elementHandler{}
+
+	var refs []string
+	if it.opts.FillMergedCells {
+		handlers["mergeCells/mergeCell"] = mergeCellHandler(&refs)
+	}
+
+	var links []Hyperlink
+	if it.opts.Hyperlinks {
+		rels, err := it.x.getSheetRelationships(sheet)
+		if err != nil {
+			return fmt.Errorf("unable to read hyperlinks: %w", err)
+		}
+		handlers["hyperlinks/hyperlink"] = hyperlinkHandler(rels, &links)
+	}
+
+	var filter *AutoFilter
+	if it.opts.SkipFilteredRows {
+		handlers["worksheet/autoFilter"] = autoFilterHandler(&filter)
+	}
+
+	if len(handlers) == 0 {
+		return nil
+	}
+	if err := it.x.scanSheet(sheet, handlers); err != nil {
+		return fmt.Errorf("unable to read sheet %s: %w", sheet, err)
+	}
+
+	var err error
+	if it.opts.FillMergedCells {
+		if it.merges, err = newMergeFill(refs); err != nil {
+			return err
+		}
+	}
+	if it.opts.Hyperlinks {
+		if it.links, err = newHyperlinkLookup(links); err != nil {
+			return err
+		}
+	}
+	if filter != nil {
+		r, err := parseCellRange(filter.Ref)
+		if err != nil {
+			return err
+		}
+		it.filter = &r
+	}
+
+	return nil
+}
+
+// Next advances the iterator to the next row containing data, which is then available from
+// Row(). It returns false once there are no more rows, or an error has occurred.
+//
+// When reading densely, Next() also stops at each of the empty rows preceding a row
+// containing data.
+func (it *RowIterator) Next() bool {
+	for len(it.queue) == 0 {
+		if !it.readRows() {
+			return false
+		}
+	}
+
+	// Empty rows are not created beyond the last row of the sheet, however large the index
+	// of the next row is
+	next := it.queue[0]
+	for it.opts.Dense && next.Error == nil && it.lastIndex+1 < min(next.Index, maxRows+1) {
+		it.lastIndex++
+		if len(it.skipped) > 0 && it.skipped[0] == it.lastIndex {
+			// Rows excluded by the options are not replaced with empty rows
+			it.skipped = it.skipped[1:]
+			continue
+		}
+		it.row = padRow(Row{Index: it.lastIndex, Cells: []Cell{}}, it.width())
+		return true
+	}
+
+	it.row, it.queue = next, it.queue[1:]
+	if it.row.Index > it.lastIndex {
+		it.lastIndex = it.row.Index
+	}
+	return true
+}
+
+// readRows reads rows from the sheet until at least one row containing data has been queued.
+// It returns false once there are no more rows, or an error has occurred.
+func (it *RowIterator) readRows() bool {
+	if it.decoder == nil && it.source == nil {
+		return false
+	}
+
+	for len(it.queue) == 0 {
+		if err := it.ctx.Err(); err != nil {
+			return it.stop(err)
+		}
+
+		row, err := it.nextRow()
+		if err == io.EOF {
+			if it.merges != nil {
+				it.enqueue(it.merges.fillRemaining()...)
+			}
+			it.stop(nil)
+			return len(it.queue) > 0
+		}
+		if err != nil {
+			return it.stop(err)
+		}
+
+		if bounds := it.opts.bounds; bounds != nil && row.Index > bounds.LastRow {
+			return it.stop(nil)
+		}
+		if it.merges != nil && row.Error == nil {
+			it.enqueue(it.merges.fillBefore(row.Index)...)
+			row = it.merges.fill(row)
+		}
+		it.enqueue(row)
+
+		if it.corrupt {
+			// The row carries the error, so the sheet's next token is not read, as it would
+			// only report the same error again
+			it.stop(nil)
+			return len(it.queue) > 0
+		}
+	}
+
+	return true
+}
+
+// nextRow reads the next row element of the sheet, noting any of the elements preceding it
+// which affect how rows are read. It returns io.EOF once there are no more rows.
+func (it *RowIterator) nextRow() (Row, error) {
+	if it.source != nil {
+		row, err := it.source.next()
+		// As with the dimension of XML sheets, a dimension outside of the sheet is ignored
+		if d := it.source.dimension(); d != nil && d.valid() {
+			it.dimension = d
+		}
+		return row, err
+	}
+
+	for {
+		token, err := it.decoder.Token()
+		if err == io.EOF {
+			return Row{}, io.EOF
+		}
+		if err != nil {
+			return Row{}, fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		startElement, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch startElement.Name.Local {
+		case "dimension":
+			it.dimension = parseDimension(startElement)
+		case "col":
+			if !it.opts.SkipHiddenColumns {
+				continue
+			}
+			column, err := parseColumn(it.decoder, startElement)
+			if err != nil {
+				return Row{}, err
+			}
+			if column.Hidden {
+				it.hidden = append(it.hidden, column)
+			}
+		case "row":
+			row, err := it.x.parseRow(it.decoder, &startElement, it.formulas, it.lastRead)
+			it.corrupt = err != nil
+			it.lastRead = row.Index
+			return row, nil
+		}
+	}
+}
+
+// enqueue processes rows according to the iterator's options, queueing those containing data.
+func (it *RowIterator) enqueue(rows ...Row) {
+	for _, row := range rows {
+		if it.opts.skipRow(row) || it.filtered(row) {
+			if it.opts.Dense {
+				it.skipped = append(it.skipped, row.Index)
+			}
+			continue
+		}
+		if it.opts.bounds != nil && row.Error == nil {
+			row = it.opts.bounds.filter(row)
+		}
+		if len(it.hidden) > 0 && row.Error == nil {
+			row = dropHiddenColumns(row, it.hidden)
+		}
+		if len(row.Cells) < 1 && row.Error == nil {
+			continue
+		}
+
+		row = it.opts.applyOptions(row)
+		if it.links != nil {
+			row = it.links.attach(row)
+		}
+		if it.comments != nil {
+			row = attachComments(row, it.comments)
+		}
+		if it.opts.Dense && row.Error == nil {
+			row = padRow(row, it.width())
+		}
+		it.queue = append(it.queue, row)
+	}
+}
+
+// filtered reports whether a row has been hidden by the sheet's auto filter, being a hidden
+// row beneath the headers of the filtered range.
+func (it *RowIterator) filtered(row Row) bool {
+	return it.filter != nil && row.Hidden && it.filter.FirstRow < row.Index && row.Index <= it.filter.LastRow
+}
+
+// stop records the error that ended iteration and releases the underlying sheet file.
+// It always returns false, so that it can be used as the result of readRows().
+func (it *RowIterator) stop(err error) bool {
+	it.err = err
+	it.row = Row{}
+	it.Close()
+	return false
+}
+
+// width gives the number of cells each row should be padded to when reading densely.
+// This is the number of columns given in the options, or else the number of columns
+// spanned by the sheet's dimension. If neither is known, it is 0.
+func (it *RowIterator) width() int {
+	if it.opts.Columns > 0 {
+		return it.opts.Columns
+	}
+	if it.dimension != nil {
+		return it.dimension.LastColumn + 1
+	}
+	return 0
+}
+
+// parseDimension parses the range of a dimension element, such as <dimension ref="A1:C3"/>.
+// It returns nil if the range is missing or cannot be parsed.
+func parseDimension(start xml.StartElement) *cellRange {
+	for _, attr := range start.Attr {
+		if attr.Name.Local != "ref" {
+			continue
+		}
+
+		// A dimension which cannot be parsed, or which lies outside of the sheet, is ignored,
+		// rather than being used to pad rows
+		r, err := parseCellRange(attr.Value)
+		if err != nil {
+			return nil
+		}
+		return &r
+	}
+
+	return nil
+}
+
+// Row returns the row most recently read by Next().
+func (it *RowIterator) Row() Row {
+	return it.row
+}
+
+// Err returns the error that caused iteration to stop, if any.
+func (it *RowIterator) Err() error {
+	return it.err
+}
+
+// Close releases the sheet file underlying the iterator. It is safe to call more than once,
+// and after Close() has been called Next() will always return false.
+func (it *RowIterator) Close() error {
+	if it.file == nil {
+		return nil
+	}
+
+	err := it.file.Close()
+	it.file = nil
+	it.decoder = nil
+	it.source = nil
+	return err
+}
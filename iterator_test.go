@@ -0,0 +1,175 @@
+package xlsxreader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIteratingRowsMatchesReadRows(t *testing.T) {
+	e, err := OpenFile("test/test-multiple-sheets.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var expected []Row
+	for row := range e.ReadRows("testSheet1") {
+		expected = append(expected, row)
+	}
+
+	it := e.Rows("testSheet1")
+	defer it.Close()
+
+	var actual []Row
+	for it.Next() {
+		actual = append(actual, it.Row())
+	}
+
+	require.NoError(t, it.Err())
+	require.Equal(t, expected, actual)
+}
+
+func TestIteratingRowsOfMissingSheet(t *testing.T) {
+	it := testFile.Rows("NonExistent")
+	defer it.Close()
+
+	require.False(t, it.Next())
+	require.EqualError(t, it.Err(), "unable to open sheet NonExistent")
+}
+
+func TestClosingRowIteratorEarly(t *testing.T) {
+	e, err := OpenFile("test/test-small.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	it := e.Rows("datarefinery_groundtruth_400000")
+	require.True(t, it.Next())
+	require.Equal(t, 1, it.Row().Index)
+
+	require.NoError(t, it.Close())
+	require.NoError(t, it.Close())
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+}
+
+func TestRangingOverAllRows(t *testing.T) {
+	e, err := OpenFile("test/test-multiple-sheets.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var expected []Row
+	for row := range e.ReadRows("testSheet1") {
+		expected = append(expected, row)
+	}
+
+	var actual []Row
+	for row, err := range e.All("testSheet1") {
+		require.NoError(t, err)
+		actual = append(actual, row)
+	}
+
+	require.Equal(t, expected, actual)
+}
+
+func TestBreakingOutOfAllRows(t *testing.T) {
+	e, err := OpenFile("test/test-small.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	count := 0
+	for range e.All("datarefinery_groundtruth_400000") {
+		count++
+		break
+	}
+
+	require.Equal(t, 1, count)
+}
+
+func TestRangingOverAllRowsOfMissingSheet(t *testing.T) {
+	var errs []error
+	for row, err := range testFile.All("NonExistent") {
+		require.True(t, errors.Is(row.Error, err))
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	require.EqualError(t, errs[0], "unable to open sheet NonExistent")
+}
+
+func TestReadingTruncatedSheet(t *testing.T) {
+	e, err := OpenFile("test/test-truncated.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var rows []Row
+	for row := range e.ReadRows("Sheet1") {
+		rows = append(rows, row)
+	}
+
+	require.Len(t, rows, 2)
+	require.NoError(t, rows[0].Error)
+	require.Equal(t, 1, rows[0].Index)
+	require.Error(t, rows[1].Error)
+	require.Equal(t, 2, rows[1].Index)
+
+	it := e.Rows("Sheet1")
+	defer it.Close()
+
+	var errorRows int
+	for it.Next() {
+		if it.Row().Error != nil {
+			errorRows++
+		}
+	}
+	require.Equal(t, 1, errorRows)
+	require.NoError(t, it.Err())
+}
+
+func TestIteratingDenselyBeyondTheLastRow(t *testing.T) {
+	e, err := OpenFile("test/test-oversized.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	it := e.RowsWithOptions("Rows", Options{Dense: true})
+	defer it.Close()
+
+	// Empty rows are created up to the last row of the sheet, but no further
+	var rows, last int
+	for it.Next() {
+		rows++
+		last = it.Row().Index
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, maxRows+1, rows)
+	require.Equal(t, 2000000000, last)
+}
+
+func TestScanningSheetForSeveralOptions(t *testing.T) {
+	e, err := OpenFile("test/test-deleted-sheet.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	refs, err := e.MergedCells("Sheet1")
+	require.NoError(t, err)
+	merges, err := newMergeFill(refs)
+	require.NoError(t, err)
+
+	links, err := e.Hyperlinks("Sheet1")
+	require.NoError(t, err)
+	lookup, err := newHyperlinkLookup(links)
+	require.NoError(t, err)
+
+	filter, err := e.AutoFilter("Sheet1")
+	require.NoError(t, err)
+	require.NotNil(t, filter)
+	bounds, err := parseCellRange(filter.Ref)
+	require.NoError(t, err)
+
+	it := e.RowsWithOptions("Sheet1", Options{FillMergedCells: true, Hyperlinks: true, SkipFilteredRows: true})
+	defer it.Close()
+
+	require.NoError(t, it.err)
+	require.Equal(t, merges, it.merges)
+	require.Equal(t, lookup, it.links)
+	require.Equal(t, &bounds, it.filter)
+}
@@ -0,0 +1,148 @@
+package xlsxreader
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+)
+
+// MergedCells returns the ranges of the cells which have been merged together within a
+// sheet, e.g. A1:C3. Only the top left cell of each range holds a value.
+func (x *XlsxFile) MergedCells(sheet string) ([]string, error) {
+	var refs []string
+
+	err := x.scanSheet(sheet, map[string]elementHandler{"mergeCells/mergeCell": mergeCellHandler(&refs)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read merged cells: %w", err)
+	}
+
+	return refs, nil
+}
+
+// mergeCellHandler returns an elementHandler which appends the range of each mergeCell
+// element to refs.
+func mergeCellHandler(refs *[]string) elementHandler {
+	return func(d *xml.Decoder, start xml.StartElement) error {
+		for _, attr := range start.Attr {
+			if attr.Name.Local == "ref" {
+				*refs = append(*refs, attr.Value)
+			}
+		}
+		return d.Skip()
+	}
+}
+
+// mergeFill copies the values of merged ranges into each of their cells, as rows are read.
+type mergeFill struct {
+	ranges  []cellRange
+	values  map[int]Cell // The top left cells of the ranges which have been read, keyed by index into ranges
+	lastRow int          // The index of the last row filled
+}
+
+// newMergeFill creates a mergeFill for the given merged ranges.
+func newMergeFill(refs []string) (*mergeFill, error) {
+	m := &mergeFill{values: map[int]Cell{}}
+
+	for _, ref := range refs {
+		// Ranges are cut off at the edges of the sheet, so that filling them creates a
+		// bounded number of rows and cells
+		r, err := parseClampedCellRange(ref)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse merged cells: %w", err)
+		}
+		m.ranges = append(m.ranges, r)
+	}
+
+	return m, nil
+}
+
+// fill copies the value of each merged range into any of its cells that fall within the row.
+// Rows must be filled in order, so that the top left cell of each range is seen first.
+func (m *mergeFill) fill(row Row) Row {
+	m.lastRow = row.Index
+
+	for i, r := range m.ranges {
+		if row.Index < r.FirstRow || r.LastRow < row.Index {
+			continue
+		}
+
+		if row.Index == r.FirstRow {
+			for _, cell := range row.Cells {
+				if cell.ColumnIndex() == r.FirstColumn {
+					m.values[i] = cell
+				}
+			}
+		}
+
+		value, ok := m.values[i]
+		if !ok {
+			continue
+		}
+
+		for column := r.FirstColumn; column <= r.LastColumn; column++ {
+			if column == r.FirstColumn && row.Index == r.FirstRow {
+				continue
+			}
+
+			cell := value
+			cell.Column = columnName(column)
+			cell.Row = row.Index
+			cell.Formula, cell.FormulaType, cell.FormulaRange = "", "", ""
+			row.Cells = setCell(row.Cells, cell)
+		}
+
+		if row.Index == r.LastRow {
+			delete(m.values, i)
+		}
+	}
+
+	return row
+}
+
+// fillBefore creates the rows between the last row filled and the given row index, for any
+// merged ranges which span them. These rows may otherwise be missing from the sheet entirely.
+func (m *mergeFill) fillBefore(index int) []Row {
+	var rows []Row
+
+	for i := m.lastRow + 1; i < index; i++ {
+		if len(m.values) == 0 {
+			m.lastRow = index - 1
+			break
+		}
+
+		if row := m.fill(Row{Index: i, Cells: []Cell{}}); len(row.Cells) > 0 {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+// fillRemaining creates the rows for any merged ranges which extend beyond the last row of the sheet.
+func (m *mergeFill) fillRemaining() []Row {
+	last := 0
+	for i := range m.values {
+		if m.ranges[i].LastRow > last {
+			last = m.ranges[i].LastRow
+		}
+	}
+
+	return m.fillBefore(last + 1)
+}
+
+// setCell places a cell within a slice of cells ordered by column, replacing any existing
+// cell in the same column.
+func setCell(cells []Cell, cell Cell) []Cell {
+	column := cell.ColumnIndex()
+	i := sort.Search(len(cells), func(i int) bool { return cells[i].ColumnIndex() >= column })
+
+	if i < len(cells) && cells[i].ColumnIndex() == column {
+		cells[i] = cell
+		return cells
+	}
+
+	cells = append(cells, Cell{})
+	copy(cells[i+1:], cells[i:])
+	cells[i] = cell
+	return cells
+}
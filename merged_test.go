@@ -0,0 +1,116 @@
+package xlsxreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGettingMergedCells(t *testing.T) {
+	e, err := OpenFile("test/test-merged-cells.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	merged, err := e.MergedCells("Report")
+	require.NoError(t, err)
+	require.Equal(t, []string{"A1:A3", "B1:C1", "D5:D6"}, merged)
+
+	merged, err = e.MergedCells("NonExistent")
+	require.EqualError(t, err, "unable to read merged cells: unable to open sheet NonExistent")
+	require.Nil(t, merged)
+}
+
+func TestReadingRowsWithoutFillingMergedCells(t *testing.T) {
+	e, err := OpenFile("test/test-merged-cells.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var indexes []int
+	for row := range e.ReadRows("Report") {
+		indexes = append(indexes, row.Index)
+	}
+	require.Equal(t, []int{1, 2, 4, 5}, indexes)
+}
+
+func TestReadingRowsFillingMergedCells(t *testing.T) {
+	e, err := OpenFile("test/test-merged-cells.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var rows []Row
+	for row := range e.ReadRowsWithOptions("Report", Options{FillMergedCells: true}) {
+		rows = append(rows, row)
+	}
+
+	require.Equal(t, []Row{
+		{Index: 1, Cells: []Cell{
+			{Column: "A", Row: 1, Value: "Category", Type: TypeString},
+			{Column: "B", Row: 1, Value: "Header", Type: TypeString},
+			{Column: "C", Row: 1, Value: "Header", Type: TypeString},
+		}},
+		{Index: 2, Cells: []Cell{
+			{Column: "A", Row: 2, Value: "Category", Type: TypeString},
+			{Column: "B", Row: 2, Value: "1", Type: TypeNumerical},
+		}},
+		{Index: 3, Cells: []Cell{
+			{Column: "A", Row: 3, Value: "Category", Type: TypeString},
+		}},
+		{Index: 4, Cells: []Cell{
+			{Column: "B", Row: 4, Value: "4", Type: TypeNumerical},
+		}},
+		{Index: 5, Cells: []Cell{
+			{Column: "D", Row: 5, Value: "Tail", Type: TypeString},
+		}},
+		{Index: 6, Cells: []Cell{
+			{Column: "D", Row: 6, Value: "Tail", Type: TypeString},
+		}},
+	}, rows)
+}
+
+func TestReadingRowsDenselyFillingMergedCells(t *testing.T) {
+	e, err := OpenFile("test/test-merged-cells.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var values [][]string
+	for row := range e.ReadRowsWithOptions("Report", Options{FillMergedCells: true, Dense: true}) {
+		var record []string
+		for _, cell := range row.Cells {
+			record = append(record, cell.Value)
+		}
+		values = append(values, record)
+	}
+
+	require.Equal(t, [][]string{
+		{"Category", "Header", "Header", ""},
+		{"Category", "1", "", ""},
+		{"Category", "", "", ""},
+		{"", "4", "", ""},
+		{"", "", "", "Tail"},
+		{"", "", "", "Tail"},
+	}, values)
+}
+
+func TestFillingMergedCellsBeyondTheLastRow(t *testing.T) {
+	m, err := newMergeFill([]string{"A1:B2000000000"})
+	require.NoError(t, err)
+	require.Equal(t, []cellRange{{FirstColumn: 0, FirstRow: 1, LastColumn: 1, LastRow: maxRows}}, m.ranges)
+
+	m.fill(Row{Index: 1, Cells: []Cell{{Column: "A", Row: 1, Value: "merged"}}})
+	rows := m.fillRemaining()
+	require.Len(t, rows, maxRows-1)
+	require.Equal(t, maxRows, rows[len(rows)-1].Index)
+}
+
+func TestSettingCells(t *testing.T) {
+	cells := []Cell{{Column: "B"}, {Column: "D"}}
+
+	cells = setCell(cells, Cell{Column: "A"})
+	cells = setCell(cells, Cell{Column: "C"})
+	cells = setCell(cells, Cell{Column: "E"})
+	cells = setCell(cells, Cell{Column: "D", Value: "replaced"})
+
+	require.Equal(t, []Cell{
+		{Column: "A"}, {Column: "B"}, {Column: "C"}, {Column: "D", Value: "replaced"}, {Column: "E"},
+	}, cells)
+}
@@ -0,0 +1,560 @@
+package xlsxreader
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenDocument spreadsheets (.ods), as saved by LibreOffice, are zip archives which hold the
+// cells of every sheet within a single content part. Rather than referring to shared strings
+// and styles, cells hold their values directly, and dates are stored as ISO 8601 text.
+// See https://docs.oasis-open.org/office/OpenDocument/v1.3/
+const (
+	openDocumentMimeType      = "application/vnd.oasis.opendocument.spreadsheet"
+	openDocumentMimeTypeName  = "mimetype"
+	openDocumentContentName   = "content.xml"
+	openDocumentSettingsName  = "settings.xml"
+	openDocumentActiveSetting = "ActiveTable"
+)
+
+// calcextNamespace is the namespace of LibreOffice's extensions to OpenDocument, which
+// include describing cells holding errors.
+const calcextNamespace = "urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0"
+
+// isOpenDocument reports whether the files of an archive are those of an OpenDocument spreadsheet.
+func isOpenDocument(files []*zip.File) bool {
+	file, err := getFileForName(files, openDocumentMimeTypeName)
+	if err != nil {
+		return false
+	}
+
+	data, err := readFile(file)
+	return err == nil && strings.TrimSpace(string(data)) == openDocumentMimeType
+}
+
+// initOpenDocument initialises the XlsxFile from the parts of an OpenDocument spreadsheet.
+func (x *XlsxFile) initOpenDocument(files []*zip.File) error {
+	content, err := getFileForName(files, openDocumentContentName)
+	if err != nil {
+		return fmt.Errorf("unable to get content: %w", err)
+	}
+
+	sheetInfo, err := getOpenDocumentSheets(content)
+	if err != nil {
+		return fmt.Errorf("unable to get worksheets: %w", err)
+	}
+
+	active, err := getOpenDocumentActiveSheet(files)
+	if err != nil {
+		return fmt.Errorf("unable to get settings: %w", err)
+	}
+
+	x.files = files
+	x.Sheets = make([]string, len(sheetInfo))
+	for i := range sheetInfo {
+		// The first sheet is shown if no other has been recorded
+		sheetInfo[i].Active = sheetInfo[i].Name == active || (active == "" && i == 0)
+		x.Sheets[i] = sheetInfo[i].Name
+	}
+	x.SheetInfo = sheetInfo
+	x.sharedStrings = []string{}
+	x.DefinedNames = []DefinedName{}
+	x.dateStyles = map[int]bool{}
+	x.format = openDocumentFormat{content: content}
+	x.doneCh = make(chan struct{})
+
+	return nil
+}
+
+// getOpenDocumentSheets reads the names of the sheets of an OpenDocument spreadsheet from its
+// content, skipping over the rows of each sheet.
+// Sheets are hidden by their style, which is described ahead of the sheets.
+func getOpenDocumentSheets(content *zip.File) ([]SheetInfo, error) {
+	rc, err := content.Open()
+	if err != nil {
+		return nil, fmt.Errorf("unable to open content: %w", err)
+	}
+	defer rc.Close()
+
+	decoder := xml.NewDecoder(rc)
+	hiddenStyles := map[string]bool{}
+	style := ""
+	sheets := []SheetInfo{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return sheets, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "style":
+				if getAttr(t, "family") == "table" {
+					style = getAttr(t, "name")
+				}
+			case "table-properties":
+				if style != "" && getAttr(t, "display") == "false" {
+					hiddenStyles[style] = true
+				}
+			case "table":
+				info := SheetInfo{
+					Name:  getAttr(t, "name"),
+					ID:    len(sheets) + 1,
+					State: SheetVisible,
+					Kind:  KindWorksheet,
+				}
+				if hiddenStyles[getAttr(t, "style-name")] {
+					info.State = SheetHidden
+				}
+				sheets = append(sheets, info)
+
+				if err := decoder.Skip(); err != nil {
+					return nil, fmt.Errorf("unable to read sheet %s: %w", info.Name, err)
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "style" {
+				style = ""
+			}
+		}
+	}
+}
+
+// getOpenDocumentActiveSheet reads the name of the sheet shown when an OpenDocument
+// spreadsheet is opened from its settings. It is empty if this has not been recorded.
+func getOpenDocumentActiveSheet(files []*zip.File) (string, error) {
+	file, err := getFileForName(files, openDocumentSettingsName)
+	if err != nil {
+		// The settings are optional
+		return "", nil
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("unable to open settings: %w", err)
+	}
+	defer rc.Close()
+
+	decoder := xml.NewDecoder(rc)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "config-item" || getAttr(start, "name") != openDocumentActiveSetting {
+			continue
+		}
+
+		var active string
+		if err := decoder.DecodeElement(&active, &start); err != nil {
+			return "", fmt.Errorf("unable to read active sheet: %w", err)
+		}
+		return active, nil
+	}
+}
+
+// getAttr gives the value of the attribute of an element with the given local name,
+// regardless of its namespace, or an empty string if there is no such attribute.
+func getAttr(start xml.StartElement, name string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// openDocumentFormat reads the sheets of OpenDocument spreadsheets.
+type openDocumentFormat struct {
+	content *zip.File
+}
+
+func (openDocumentFormat) name() string {
+	return "ods"
+}
+
+func (f openDocumentFormat) openRows(x *XlsxFile, sheet string) (rowSource, error) {
+	if !slices.Contains(x.Sheets, sheet) {
+		return nil, fmt.Errorf("unable to open sheet %s", sheet)
+	}
+
+	rc, err := f.content.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &openDocumentSheet{file: rc, decoder: xml.NewDecoder(rc), sheet: sheet}, nil
+}
+
+// openDocumentSheet reads the rows of a sheet of an OpenDocument spreadsheet.
+// Runs of identical rows and cells are stored once, along with the number of times they
+// are repeated. Empty runs, which commonly stretch to the last row and column of the sheet,
+// are skipped over, whilst rows holding data are repeated as they are read.
+type openDocumentSheet struct {
+	file     io.ReadCloser
+	decoder  *xml.Decoder
+	sheet    string
+	started  bool // Whether the sheet has been found within the content
+	done     bool // Whether the end of the sheet has been read
+	index    int  // The index of the last row read
+	repeated Row  // The last row read, which is yet to be repeated
+	repeats  int  // The number of times the last row is yet to be repeated
+}
+
+func (s *openDocumentSheet) next() (Row, error) {
+	if s.repeats > 0 {
+		s.repeats--
+		s.index++
+		return repeatRow(s.repeated, s.index), nil
+	}
+
+	for !s.done {
+		token, err := s.decoder.Token()
+		if err == io.EOF {
+			if !s.started {
+				return Row{}, fmt.Errorf("unable to find sheet %s", s.sheet)
+			}
+			return Row{}, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return Row{}, fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if !s.started {
+				if t.Name.Local == "table" {
+					s.started = getAttr(t, "name") == s.sheet
+					if !s.started {
+						if err := s.decoder.Skip(); err != nil {
+							return Row{}, fmt.Errorf("error retrieving xml token: %w", err)
+						}
+					}
+				}
+				continue
+			}
+			if t.Name.Local != "table-row" {
+				continue
+			}
+
+			row, repeats, err := parseOpenDocumentRow(s.decoder, t, s.index+1)
+			if err != nil {
+				return Row{}, err
+			}
+			if len(row.Cells) == 0 && row.Error == nil {
+				s.index += repeats
+				continue
+			}
+
+			// Likewise, rows are not repeated beyond the last row of the sheet
+			s.index++
+			s.repeated, s.repeats = row, max(min(repeats-1, maxRows-s.index), 0)
+			return row, nil
+		case xml.EndElement:
+			// The contents of cells are read along with them, so this ends the sheet
+			s.done = s.started && t.Name.Local == "table"
+		}
+	}
+
+	return Row{}, io.EOF
+}
+
+// dimension gives nil, as OpenDocument spreadsheets do not declare the range of used cells.
+func (s *openDocumentSheet) dimension() *cellRange {
+	return nil
+}
+
+func (s *openDocumentSheet) Close() error {
+	return s.file.Close()
+}
+
+// repeatRow gives a copy of a row at another index.
+func repeatRow(row Row, index int) Row {
+	row.Index = index
+	cells := make([]Cell, len(row.Cells))
+	for i, cell := range row.Cells {
+		cell.Row = index
+		cells[i] = cell
+	}
+	row.Cells = cells
+	return row
+}
+
+// parseOpenDocumentRow parses a row element, along with the number of times it is repeated.
+// Errors interpreting the values of its cells are reported on the row, whereas errors
+// reading the XML are returned.
+func parseOpenDocumentRow(d *xml.Decoder, start xml.StartElement, index int) (Row, int, error) {
+	repeats, err := parseRepeats(start, "number-rows-repeated")
+	if err != nil {
+		return Row{}, 0, err
+	}
+
+	visibility := getAttr(start, "visibility")
+	row := Row{
+		Index:  index,
+		Hidden: visibility == "collapse" || visibility == "filter",
+		Cells:  []Cell{},
+	}
+
+	column := 0
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return Row{}, 0, fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "table-cell" && t.Name.Local != "covered-table-cell" {
+				continue
+			}
+
+			c, err := parseOpenDocumentCell(d, t)
+			if err != nil {
+				return Row{}, 0, err
+			}
+			cell, ok, err := c.toCell(index)
+			if err != nil && row.Error == nil {
+				row.Error = fmt.Errorf("unable to read cell %s%d: %w", columnName(column), index, err)
+			}
+			// Repeats are bounded by the last column of the sheet, as a cell repeated beyond
+			// it would otherwise be expanded into an unbounded number of cells
+			for i := 0; ok && i < c.repeats && column+i < maxColumns; i++ {
+				cell.Column = columnName(column + i)
+				row.Cells = append(row.Cells, cell)
+			}
+			column = min(column+c.repeats, maxColumns)
+		case xml.EndElement:
+			if t.Name.Local != "table-row" {
+				continue
+			}
+			if row.Error != nil {
+				return Row{Index: index, Error: row.Error}, repeats, nil
+			}
+			return row, repeats, nil
+		}
+	}
+}
+
+// parseRepeats parses the number of times a row or cell is repeated, which is 1 by default.
+func parseRepeats(start xml.StartElement, name string) (int, error) {
+	value := getAttr(start, name)
+	if value == "" {
+		return 1, nil
+	}
+
+	repeats, err := strconv.Atoi(value)
+	if err != nil || repeats < 1 {
+		return 0, fmt.Errorf("unable to parse %s %q", name, value)
+	}
+	return repeats, nil
+}
+
+// openDocumentCell is the raw representation of a cell of an OpenDocument spreadsheet.
+type openDocumentCell struct {
+	repeats      int
+	valueType    string
+	errorValue   bool
+	value        string
+	dateValue    string
+	timeValue    string
+	booleanValue string
+	stringValue  *string
+	text         string
+}
+
+// parseOpenDocumentCell parses a cell element, reading the text of its paragraphs.
+func parseOpenDocumentCell(d *xml.Decoder, start xml.StartElement) (openDocumentCell, error) {
+	repeats, err := parseRepeats(start, "number-columns-repeated")
+	if err != nil {
+		return openDocumentCell{}, err
+	}
+
+	c := openDocumentCell{repeats: repeats}
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "value-type":
+			if attr.Name.Space == calcextNamespace {
+				c.errorValue = attr.Value == "error"
+				continue
+			}
+			c.valueType = attr.Value
+		case "value":
+			c.value = attr.Value
+		case "date-value":
+			c.dateValue = attr.Value
+		case "time-value":
+			c.timeValue = attr.Value
+		case "boolean-value":
+			c.booleanValue = attr.Value
+		case "string-value":
+			c.stringValue = &attr.Value
+		}
+	}
+
+	if c.text, err = readOpenDocumentText(d); err != nil {
+		return openDocumentCell{}, err
+	}
+	return c, nil
+}
+
+// readOpenDocumentText reads the text of the paragraphs within an element, up to the end of
+// the element. Paragraphs are separated by new lines. Annotations, which are the comments
+// attached to cells, are not part of the text.
+func readOpenDocumentText(d *xml.Decoder) (string, error) {
+	var b strings.Builder
+	paragraphs, depth := 0, 0
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return "", fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "annotation":
+				if err := d.Skip(); err != nil {
+					return "", fmt.Errorf("error retrieving xml token: %w", err)
+				}
+				continue
+			case "p", "h":
+				if depth == 0 {
+					if paragraphs > 0 {
+						b.WriteByte('\n')
+					}
+					paragraphs++
+				}
+			case "s":
+				// Runs of spaces are stored as a count, which is 1 by default
+				count, err := parseRepeats(t, "c")
+				if err != nil {
+					return "", err
+				}
+				b.WriteString(strings.Repeat(" ", count))
+			case "tab":
+				b.WriteByte('\t')
+			case "line-break":
+				b.WriteByte('\n')
+			}
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				return b.String(), nil
+			}
+			depth--
+		case xml.CharData:
+			if depth > 0 {
+				b.Write(t)
+			}
+		}
+	}
+}
+
+// toCell converts the raw cell into a consumable Cell, giving false if the cell is empty.
+// Dates and times are formatted in the same way as those of xlsx files, being stored
+// alongside the serial number Excel would store them as.
+func (c openDocumentCell) toCell(index int) (Cell, bool, error) {
+	cell := Cell{Row: index}
+	switch {
+	case c.errorValue:
+		cell.Value, cell.Type = c.text, TypeError
+	case c.valueType == "float" || c.valueType == "percentage" || c.valueType == "currency":
+		cell.Value, cell.Type = c.value, TypeNumerical
+	case c.valueType == "boolean":
+		cell.Value, cell.Type = "0", TypeBoolean
+		if c.booleanValue == "true" {
+			cell.Value = "1"
+		}
+	case c.valueType == "date":
+		t, err := parseISODate(c.dateValue)
+		if err != nil {
+			return Cell{}, false, err
+		}
+		cell.Value, cell.serial = formatDate(t, false)
+		cell.Type = TypeDateTime
+	case c.valueType == "time":
+		d, err := parseOpenDocumentDuration(c.timeValue)
+		if err != nil {
+			return Cell{}, false, err
+		}
+		cell.Value, cell.serial = formatDate(excelEpoch.Add(d), false)
+		cell.Type = TypeDateTime
+	case c.valueType == "string":
+		cell.Value, cell.Type = c.text, TypeString
+		if c.stringValue != nil {
+			cell.Value = *c.stringValue
+		}
+	default:
+		// Cells without a type of value are empty
+		return Cell{}, false, nil
+	}
+
+	return cell, true, nil
+}
+
+// parseOpenDocumentDuration parses an ISO 8601 duration, which is how times are stored,
+// such as PT06H30M00S. Durations may also be given in days, but not in months or years.
+func parseOpenDocumentDuration(value string) (time.Duration, error) {
+	s, negative := strings.CutPrefix(value, "-")
+	s, ok := strings.CutPrefix(s, "P")
+	if !ok || s == "" {
+		return 0, fmt.Errorf("unable to parse %q as a duration", value)
+	}
+
+	var d time.Duration
+	inTime := false
+	number := ""
+	for _, r := range s {
+		if r >= '0' && r <= '9' || r == '.' || r == ',' {
+			number += string(r)
+			continue
+		}
+		if r == 'T' && number == "" {
+			inTime = true
+			continue
+		}
+
+		f, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", "."), 64)
+		if err != nil {
+			return 0, fmt.Errorf("unable to parse %q as a duration", value)
+		}
+		number = ""
+
+		switch {
+		case r == 'D' && !inTime:
+			d += time.Duration(f * float64(24*time.Hour))
+		case r == 'H' && inTime:
+			d += time.Duration(f * float64(time.Hour))
+		case r == 'M' && inTime:
+			d += time.Duration(f * float64(time.Minute))
+		case r == 'S' && inTime:
+			d += time.Duration(f * float64(time.Second))
+		default:
+			return 0, fmt.Errorf("unable to parse %q as a duration", value)
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("unable to parse %q as a duration", value)
+	}
+
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
@@ -0,0 +1,171 @@
+package xlsxreader
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var openDocumentDurationTests = []struct {
+	Value    string
+	Expected time.Duration
+	Error    bool
+}{
+	{Value: "PT06H00M00S", Expected: 6 * time.Hour},
+	{Value: "PT12H30M15.5S", Expected: 12*time.Hour + 30*time.Minute + 15500*time.Millisecond},
+	{Value: "P1DT2H", Expected: 26 * time.Hour},
+	{Value: "-PT1H", Expected: -time.Hour},
+	{Value: "P1M", Error: true},
+	{Value: "PT1", Error: true},
+	{Value: "06:00:00", Error: true},
+}
+
+func TestParsingOpenDocumentDurations(t *testing.T) {
+	for _, test := range openDocumentDurationTests {
+		t.Run(test.Value, func(t *testing.T) {
+			d, err := parseOpenDocumentDuration(test.Value)
+			if test.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, d)
+		})
+	}
+}
+
+// readOpenDocumentSheet reads the rows of a sheet from the XML of a spreadsheet's content.
+func readOpenDocumentSheet(t *testing.T, tables string) []Row {
+	content := `<office:document-content
+		xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+		xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+		xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+		<office:body><office:spreadsheet>` + tables + `</office:spreadsheet></office:body></office:document-content>`
+
+	s := &openDocumentSheet{
+		file:    io.NopCloser(strings.NewReader(content)),
+		decoder: xml.NewDecoder(strings.NewReader(content)),
+		sheet:   "Sheet1",
+	}
+
+	var rows []Row
+	for {
+		row, err := s.next()
+		if err == io.EOF {
+			return rows
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+var openDocumentRepeatTests = []struct {
+	Name    string
+	Rows    string
+	Indices []int
+	Columns []string
+}{
+	{
+		Name:    "Repeated cells",
+		Rows:    `<table:table-row><table:table-cell/><table:table-cell office:value-type="float" office:value="1" table:number-columns-repeated="3"/></table:table-row>`,
+		Indices: []int{1},
+		Columns: []string{"B", "C", "D"},
+	},
+	{
+		Name:    "Repeated rows",
+		Rows:    `<table:table-row table:number-rows-repeated="2"/><table:table-row table:number-rows-repeated="3"><table:table-cell office:value-type="float" office:value="1"/></table:table-row>`,
+		Indices: []int{3, 4, 5},
+		Columns: []string{"A"},
+	},
+	{
+		Name:    "Repeated beyond the last column",
+		Rows:    `<table:table-row><table:table-cell table:number-columns-repeated="16383"/><table:table-cell office:value-type="float" office:value="1" table:number-columns-repeated="2000000000"/><table:table-cell office:value-type="float" office:value="2"/></table:table-row>`,
+		Indices: []int{1},
+		Columns: []string{"XFD"},
+	},
+	{
+		Name:    "Trailing empty cells",
+		Rows:    `<table:table-row><table:table-cell office:value-type="float" office:value="1"/><table:table-cell table:number-columns-repeated="2000000000"/></table:table-row><table:table-row table:number-rows-repeated="2000000000"><table:table-cell table:number-columns-repeated="16384"/></table:table-row>`,
+		Indices: []int{1},
+		Columns: []string{"A"},
+	},
+	{
+		Name:    "Repeated beyond the last row",
+		Rows:    `<table:table-row table:number-rows-repeated="1048574"/><table:table-row table:number-rows-repeated="2000000000"><table:table-cell office:value-type="float" office:value="1"/></table:table-row>`,
+		Indices: []int{1048575, 1048576},
+		Columns: []string{"A"},
+	},
+}
+
+func TestReadingRepeatedOpenDocumentRows(t *testing.T) {
+	for _, test := range openDocumentRepeatTests {
+		t.Run(test.Name, func(t *testing.T) {
+			rows := readOpenDocumentSheet(t, `<table:table table:name="Other">`+test.Rows+`</table:table>`+
+				`<table:table table:name="Sheet1">`+test.Rows+`</table:table>`)
+
+			var indices []int
+			for _, row := range rows {
+				require.NoError(t, row.Error)
+				indices = append(indices, row.Index)
+
+				var columns []string
+				for _, cell := range row.Cells {
+					require.Equal(t, row.Index, cell.Row)
+					columns = append(columns, cell.Column)
+				}
+				require.Equal(t, test.Columns, columns)
+			}
+			require.Equal(t, test.Indices, indices)
+		})
+	}
+}
+
+var openDocumentCellTests = []struct {
+	Name     string
+	Cell     string
+	Expected Cell
+}{
+	{
+		Name:     "Text",
+		Cell:     `<table:table-cell office:value-type="string"><text:p>two<text:s text:c="2"/>spaces</text:p><text:p>and <text:span>lines</text:span></text:p><office:annotation><text:p>a comment</text:p></office:annotation></table:table-cell>`,
+		Expected: Cell{Column: "A", Row: 1, Value: "two  spaces\nand lines", Type: TypeString},
+	},
+	{
+		Name:     "Date",
+		Cell:     `<table:table-cell office:value-type="date" office:date-value="2019-01-24T06:00:00"><text:p>24/01/19 06:00</text:p></table:table-cell>`,
+		Expected: Cell{Column: "A", Row: 1, Value: "2019-01-24T06:00:00Z", Type: TypeDateTime, serial: "43489.25"},
+	},
+	{
+		Name:     "Time",
+		Cell:     `<table:table-cell office:value-type="time" office:time-value="PT12H30M15S"><text:p>12:30:15</text:p></table:table-cell>`,
+		Expected: Cell{Column: "A", Row: 1, Value: "1899-12-30T12:30:15Z", Type: TypeDateTime, serial: "0.5210069444444444"},
+	},
+}
+
+func TestReadingOpenDocumentCells(t *testing.T) {
+	for _, test := range openDocumentCellTests {
+		t.Run(test.Name, func(t *testing.T) {
+			rows := readOpenDocumentSheet(t, `<table:table table:name="Sheet1"><table:table-row>`+test.Cell+`</table:table-row></table:table>`)
+			require.Len(t, rows, 1)
+			require.Equal(t, []Cell{test.Expected}, rows[0].Cells)
+		})
+	}
+}
+
+func TestReadingOpenDocumentTimes(t *testing.T) {
+	rows := readOpenDocumentSheet(t, `<table:table table:name="Sheet1"><table:table-row>`+
+		openDocumentCellTests[1].Cell+openDocumentCellTests[2].Cell+`</table:table-row></table:table>`)
+	require.Len(t, rows, 1)
+
+	date, err := rows[0].Cells[0].Time()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 1, 24, 6, 0, 0, 0, time.UTC), date)
+
+	duration, err := rows[0].Cells[1].Duration()
+	require.NoError(t, err)
+	require.Equal(t, 12*time.Hour+30*time.Minute+15*time.Second, duration)
+}
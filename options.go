@@ -0,0 +1,67 @@
+package xlsxreader
+
+// Options configures how rows are read from a worksheet.
+// The zero value reads rows in the same way as ReadRows.
+type Options struct {
+	// CellErrorsAsErrors reports cells containing an error, such as #N/A, as errors rather
+	// than values. The cell's Value is left empty and its Error field is set to a
+	// *CellValueError, and the errors of all such cells are reported on the Row's Error field.
+	CellErrorsAsErrors bool
+
+	// Dense fills in the rows and cells omitted from the sheet because they are empty.
+	// Every row from the first up to the last row containing data is read, and each row
+	// has a cell for every column from A onwards, with empty cells having an empty Value.
+	// Cells beyond the width of the sheet are never discarded.
+	Dense bool
+	// Columns sets the width rows are padded to when reading densely. If it is not set,
+	// the width is taken from the range of used cells declared by the sheet, or if that is
+	// missing, each row is only padded up to its last cell.
+	Columns int
+
+	// FillMergedCells copies the value of the top left cell of each merged range into every
+	// other cell of the range, as though the value had been entered into each of them.
+	FillMergedCells bool
+
+	// Hyperlinks attaches any hyperlink a cell has to the cell's Hyperlink field.
+	Hyperlinks bool
+
+	// Comments attaches any comment a cell has to the cell's Comment field. Note that
+	// comments attached to empty cells are not read, as empty cells are omitted.
+	Comments bool
+
+	// SkipHiddenRows omits rows which are hidden, including those hidden by a filter or
+	// within a collapsed outline group.
+	SkipHiddenRows bool
+	// SkipCollapsedRows omits only the hidden rows within a collapsed outline group.
+	SkipCollapsedRows bool
+
+	// SkipFilteredRows omits the rows hidden by the sheet's auto filter, so that only the
+	// rows of the filtered range which Excel displays are read. Rows which have been hidden
+	// in other ways are still read, unless SkipHiddenRows is also set.
+	SkipFilteredRows bool
+
+	// SkipHiddenColumns omits the cells of columns which are hidden. When reading densely,
+	// the cells of hidden columns are left empty rather than removed, so that the cells of
+	// each row remain aligned.
+	SkipHiddenColumns bool
+
+	bounds *cellRange // Restricts reading to the cells within the range
+}
+
+// skipRow reports whether the options exclude a row that has been read.
+func (o Options) skipRow(row Row) bool {
+	if !row.Hidden {
+		return false
+	}
+
+	return o.SkipHiddenRows || (o.SkipCollapsedRows && row.OutlineLevel > 0)
+}
+
+// applyOptions performs any processing required by the options on a row that has been read.
+func (o Options) applyOptions(row Row) Row {
+	if o.CellErrorsAsErrors {
+		row = surfaceCellErrors(row)
+	}
+
+	return row
+}
@@ -0,0 +1,178 @@
+package xlsxreader
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Properties holds the metadata of a workbook, as recorded by the application which saved it.
+// Any properties which have not been recorded are left empty.
+type Properties struct {
+	Title          string
+	Subject        string
+	Creator        string
+	Keywords       string
+	Description    string
+	LastModifiedBy string
+	Category       string
+	Created        time.Time
+	Modified       time.Time
+
+	Application string // E.G   Microsoft Excel
+	AppVersion  string // E.G   16.0300
+	Company     string
+	Manager     string
+
+	Custom []CustomProperty
+}
+
+// CustomProperty is a property of a workbook defined by its author, or by another application.
+// Value holds a string, int64, uint64, float64, bool or time.Time, depending upon the type of
+// the property. Values of any other type are given as a string.
+type CustomProperty struct {
+	Name  string
+	Value interface{}
+}
+
+// rawCoreProperties represents the Dublin Core properties of docProps/core.xml.
+type rawCoreProperties struct {
+	Title          string `xml:"title"`
+	Subject        string `xml:"subject"`
+	Creator        string `xml:"creator"`
+	Keywords       string `xml:"keywords"`
+	Description    string `xml:"description"`
+	LastModifiedBy string `xml:"lastModifiedBy"`
+	Category       string `xml:"category"`
+	Created        string `xml:"created"`
+	Modified       string `xml:"modified"`
+}
+
+// rawAppProperties represents the extended properties of docProps/app.xml.
+type rawAppProperties struct {
+	Application string `xml:"Application"`
+	AppVersion  string `xml:"AppVersion"`
+	Company     string `xml:"Company"`
+	Manager     string `xml:"Manager"`
+}
+
+// rawCustomProperties represents the custom properties of docProps/custom.xml.
+type rawCustomProperties struct {
+	Properties []struct {
+		Name  string `xml:"name,attr"`
+		Value struct {
+			XMLName xml.Name
+			Text    string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"property"`
+}
+
+// Properties reads the metadata of the workbook, such as its author, and when it was created.
+func (x *XlsxFile) Properties() (Properties, error) {
+	rels, err := getPartRelationships(x.files, "")
+	if err != nil {
+		return Properties{}, fmt.Errorf("unable to read properties: %w", err)
+	}
+
+	var properties Properties
+
+	var core rawCoreProperties
+	found, err := unmarshalProperties(x.files, rels, "core-properties", "docProps/core.xml", &core)
+	if err != nil {
+		return Properties{}, err
+	}
+	if found {
+		properties.Title = core.Title
+		properties.Subject = core.Subject
+		properties.Creator = core.Creator
+		properties.Keywords = core.Keywords
+		properties.Description = core.Description
+		properties.LastModifiedBy = core.LastModifiedBy
+		properties.Category = core.Category
+
+		if properties.Created, err = parsePropertyDate(core.Created); err != nil {
+			return Properties{}, fmt.Errorf("unable to read properties: %w", err)
+		}
+		if properties.Modified, err = parsePropertyDate(core.Modified); err != nil {
+			return Properties{}, fmt.Errorf("unable to read properties: %w", err)
+		}
+	}
+
+	var app rawAppProperties
+	found, err = unmarshalProperties(x.files, rels, "extended-properties", "docProps/app.xml", &app)
+	if err != nil {
+		return Properties{}, err
+	}
+	if found {
+		properties.Application = app.Application
+		properties.AppVersion = app.AppVersion
+		properties.Company = app.Company
+		properties.Manager = app.Manager
+	}
+
+	var custom rawCustomProperties
+	found, err = unmarshalProperties(x.files, rels, "custom-properties", "docProps/custom.xml", &custom)
+	if err != nil {
+		return Properties{}, err
+	}
+	properties.Custom = []CustomProperty{}
+	if found {
+		for _, p := range custom.Properties {
+			value, err := parseVariant(p.Value.XMLName.Local, p.Value.Text)
+			if err != nil {
+				return Properties{}, fmt.Errorf("unable to read custom property %s: %w", p.Name, err)
+			}
+			properties.Custom = append(properties.Custom, CustomProperty{Name: p.Name, Value: value})
+		}
+	}
+
+	return properties, nil
+}
+
+// unmarshalProperties unmarshals the XML of a properties part into v. The part is found
+// through the package's relationships, or at its usual location if there is no relationship.
+// It reports whether the part exists.
+func unmarshalProperties(files []*zip.File, rels []relationship, typ string, defaultName string, v interface{}) (bool, error) {
+	name := defaultName
+	if found := getRelationshipsOfType(rels, typ); len(found) > 0 {
+		name = resolveRelationshipTarget("", found[0])
+	}
+
+	if _, err := getFileForName(files, name); err != nil {
+		return false, nil
+	}
+	if err := unmarshalFile(files, name, v); err != nil {
+		return false, fmt.Errorf("unable to read properties: %w", err)
+	}
+	return true, nil
+}
+
+// parsePropertyDate parses the date of a property, which is empty if it was not recorded.
+func parsePropertyDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return parseISODate(value)
+}
+
+// parseVariant converts the text of a typed property value into the corresponding Go type.
+func parseVariant(typ string, text string) (interface{}, error) {
+	switch typ {
+	case "i1", "i2", "i4", "i8", "int":
+		return strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	case "ui1", "ui2", "ui4", "ui8", "uint":
+		return strconv.ParseUint(strings.TrimSpace(text), 10, 64)
+	case "r4", "r8", "decimal":
+		return strconv.ParseFloat(strings.TrimSpace(text), 64)
+	case "bool":
+		return strconv.ParseBool(strings.TrimSpace(text))
+	case "filetime", "date":
+		return parseISODate(strings.TrimSpace(text))
+	default:
+		return text, nil
+	}
+}
@@ -0,0 +1,90 @@
+package xlsxreader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGettingProperties(t *testing.T) {
+	e, err := OpenFile("test/test-properties.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	properties, err := e.Properties()
+	require.NoError(t, err)
+	require.Equal(t, Properties{
+		Title:          "Quarterly figures",
+		Subject:        "Sales",
+		Creator:        "Alex Doe",
+		Keywords:       "sales; q1",
+		Description:    "Figures for the first quarter",
+		LastModifiedBy: "Sam Roe",
+		Category:       "Finance",
+		Created:        time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC),
+		Modified:       time.Date(2024, 3, 4, 17, 45, 10, 0, time.UTC),
+		Application:    "Microsoft Excel",
+		AppVersion:     "16.0300",
+		Company:        "Example Ltd",
+		Manager:        "Jo Poe",
+		Custom: []CustomProperty{
+			{Name: "Department", Value: "Sales"},
+			{Name: "Version", Value: int64(3)},
+			{Name: "Approved", Value: true},
+			{Name: "Due", Value: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+			{Name: "Rate", Value: 0.125},
+		},
+	}, properties)
+}
+
+func TestGettingPropertiesThroughRelationships(t *testing.T) {
+	e, err := OpenFile("test/test-bold.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	properties, err := e.Properties()
+	require.NoError(t, err)
+	require.Equal(t, Properties{
+		Modified:    time.Date(2020, 10, 22, 13, 10, 41, 0, time.UTC),
+		Application: "gnumeric",
+		AppVersion:  "1.1247",
+		Custom:      []CustomProperty{},
+	}, properties)
+}
+
+func TestGettingMissingProperties(t *testing.T) {
+	e, err := OpenFile("test/test-dense.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	properties, err := e.Properties()
+	require.NoError(t, err)
+	require.Equal(t, Properties{Custom: []CustomProperty{}}, properties)
+}
+
+var variantTests = []struct {
+	Type     string
+	Text     string
+	Expected interface{}
+}{
+	{Type: "i4", Text: "-12", Expected: int64(-12)},
+	{Type: "ui4", Text: "12", Expected: uint64(12)},
+	{Type: "ui8", Text: "18446744073709551615", Expected: uint64(18446744073709551615)},
+	{Type: "r8", Text: "0.5", Expected: 0.5},
+	{Type: "bool", Text: "true", Expected: true},
+	{Type: "lpwstr", Text: " text ", Expected: " text "},
+}
+
+func TestParsingVariants(t *testing.T) {
+	for _, test := range variantTests {
+		t.Run(test.Type, func(t *testing.T) {
+			value, err := parseVariant(test.Type, test.Text)
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, value)
+		})
+	}
+
+	_, err := parseVariant("ui4", "-1")
+	require.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package xlsxreader
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// splitCellReference splits a cell reference such as B12 into a zero based column index
+// and a row number. Absolute markers are ignored, so $B$12 gives the same result as B12.
+// References beyond the last column or row of a sheet, XFD1048576, are invalid.
+func splitCellReference(ref string) (int, int, error) {
+	column, row, err := splitUnboundedCellReference(ref)
+	if err != nil {
+		return 0, 0, err
+	}
+	if column >= maxColumns || row > maxRows {
+		return 0, 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return column, row, nil
+}
+
+// splitUnboundedCellReference splits a cell reference in the same way as splitCellReference,
+// but allows references beyond the last column or row of a sheet. Columns and rows too large
+// to be represented are given as the first column or row beyond the sheet.
+func splitUnboundedCellReference(ref string) (int, int, error) {
+	ref = strings.ReplaceAll(ref, "$", "")
+
+	i := 0
+	for i < len(ref) && ('A' <= ref[i] && ref[i] <= 'Z' || 'a' <= ref[i] && ref[i] <= 'z') {
+		i++
+	}
+	if i == 0 || i == len(ref) {
+		return 0, 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+
+	row, err := strconv.Atoi(ref[i:])
+	if errors.Is(err, strconv.ErrRange) && ref[i] != '-' {
+		row, err = maxRows+1, nil
+	}
+	if err != nil || row < 1 {
+		return 0, 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+
+	column := maxColumns
+	if i <= maxColumnLetters {
+		column = min(asIndex(strings.Map(removeNonAlpha, ref[:i])), maxColumns)
+	}
+
+	return column, row, nil
+}
+
+// columnName gives the name of the column at a zero based index. 0 -> 'A', 25 -> 'Z', 26 -> 'AA'
+func columnName(index int) string {
+	var name []byte
+	for index >= 0 {
+		name = append([]byte{byte('A' + index%26)}, name...)
+		index = index/26 - 1
+	}
+	return string(name)
+}
+
+// cellRange is a rectangular range of cells, such as A1:C3.
+// Columns are zero based indexes, whereas rows are row numbers.
+type cellRange struct {
+	FirstColumn int
+	FirstRow    int
+	LastColumn  int
+	LastRow     int
+}
+
+// parseCellRange parses a range reference such as A1:C3. A reference to a single cell,
+// such as B2, is treated as a range containing only that cell.
+func parseCellRange(ref string) (cellRange, error) {
+	return parseRange(ref, splitCellReference)
+}
+
+// parseClampedCellRange parses a range reference in the same way as parseCellRange, but with
+// any part of the range beyond the last column or row of a sheet cut off, rather than the
+// range being invalid. A range lying entirely beyond the sheet is still invalid.
+func parseClampedCellRange(ref string) (cellRange, error) {
+	r, err := parseRange(ref, splitUnboundedCellReference)
+	if err != nil {
+		return cellRange{}, err
+	}
+	if r.FirstColumn >= maxColumns || r.FirstRow > maxRows {
+		return cellRange{}, fmt.Errorf("invalid range %q: range is beyond the sheet", ref)
+	}
+
+	r.LastColumn = min(r.LastColumn, maxColumns-1)
+	r.LastRow = min(r.LastRow, maxRows)
+	return r, nil
+}
+
+// parseRange parses a range reference, using split to parse each of the cells it spans.
+func parseRange(ref string, split func(string) (int, int, error)) (cellRange, error) {
+	first, last := ref, ref
+	if i := strings.IndexByte(ref, ':'); i >= 0 {
+		first, last = ref[:i], ref[i+1:]
+	}
+
+	var (
+		r   cellRange
+		err error
+	)
+	if r.FirstColumn, r.FirstRow, err = split(first); err != nil {
+		return cellRange{}, fmt.Errorf("invalid range %q: %w", ref, err)
+	}
+	if r.LastColumn, r.LastRow, err = split(last); err != nil {
+		return cellRange{}, fmt.Errorf("invalid range %q: %w", ref, err)
+	}
+
+	if r.FirstColumn > r.LastColumn {
+		r.FirstColumn, r.LastColumn = r.LastColumn, r.FirstColumn
+	}
+	if r.FirstRow > r.LastRow {
+		r.FirstRow, r.LastRow = r.LastRow, r.FirstRow
+	}
+
+	return r, nil
+}
+
+// valid reports whether the range lies within the bounds of a sheet.
+func (r cellRange) valid() bool {
+	return 0 <= r.FirstColumn && r.FirstColumn <= r.LastColumn && r.LastColumn < maxColumns &&
+		1 <= r.FirstRow && r.FirstRow <= r.LastRow && r.LastRow <= maxRows
+}
+
+// contains reports whether the cell at the given column index and row number lies within the range.
+func (r cellRange) contains(column, row int) bool {
+	return r.FirstColumn <= column && column <= r.LastColumn && r.FirstRow <= row && row <= r.LastRow
+}
+
+// splitSheetReference splits a reference qualified by a sheet name, such as Sheet1!A1:C3 or
+// 'My Sheet'!A1, into the unquoted sheet name and the reference within the sheet.
+// References without a sheet name give an empty sheet name.
+func splitSheetReference(ref string) (string, string) {
+	if strings.HasPrefix(ref, "'") {
+		end := skipQuoted(ref, 0)
+		if end < len(ref) && ref[end] == '!' {
+			return strings.ReplaceAll(ref[1:end-1], "''", "'"), ref[end+1:]
+		}
+	}
+
+	i := strings.IndexByte(ref, '!')
+	if i < 0 {
+		return "", ref
+	}
+	return ref[:i], ref[i+1:]
+}
+
+// filter removes the cells of a row which lie outside of the range.
+func (r cellRange) filter(row Row) Row {
+	if row.Index < r.FirstRow || r.LastRow < row.Index {
+		row.Cells = []Cell{}
+		return row
+	}
+
+	cells := make([]Cell, 0, len(row.Cells))
+	for _, cell := range row.Cells {
+		if column := cell.ColumnIndex(); r.FirstColumn <= column && column <= r.LastColumn {
+			cells = append(cells, cell)
+		}
+	}
+	row.Cells = cells
+	return row
+}
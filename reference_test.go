@@ -0,0 +1,144 @@
+package xlsxreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var splitCellReferenceTests = []struct {
+	Reference string
+	Column    int
+	Row       int
+	Error     string
+}{
+	{Reference: "A1", Column: 0, Row: 1},
+	{Reference: "B12", Column: 1, Row: 12},
+	{Reference: "$AA$3", Column: 26, Row: 3},
+	{Reference: "aa3", Column: 26, Row: 3},
+	{Reference: "A", Error: `invalid cell reference "A"`},
+	{Reference: "12", Error: `invalid cell reference "12"`},
+	{Reference: "A0", Error: `invalid cell reference "A0"`},
+	{Reference: "A1B", Error: `invalid cell reference "A1B"`},
+	{Reference: "XFD1048576", Column: 16383, Row: 1048576},
+	{Reference: "XFE1", Error: `invalid cell reference "XFE1"`},
+	{Reference: "A1048577", Error: `invalid cell reference "A1048577"`},
+	{Reference: "ZZZZZZZ1", Error: `invalid cell reference "ZZZZZZZ1"`},
+	{Reference: "A99999999999999999999", Error: `invalid cell reference "A99999999999999999999"`},
+}
+
+func TestSplittingCellReferences(t *testing.T) {
+	for _, test := range splitCellReferenceTests {
+		t.Run(test.Reference, func(t *testing.T) {
+			column, row, err := splitCellReference(test.Reference)
+
+			if test.Error != "" {
+				require.EqualError(t, err, test.Error)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.Column, column)
+			require.Equal(t, test.Row, row)
+		})
+	}
+}
+
+func TestColumnNames(t *testing.T) {
+	for _, name := range []string{"A", "B", "Z", "AA", "AZ", "BA", "ZZ", "AAA", "ZZZ", "AAAA"} {
+		require.Equal(t, name, columnName(asIndex(name)))
+	}
+}
+
+var parseCellRangeTests = []struct {
+	Reference string
+	Expected  cellRange
+	Error     string
+}{
+	{Reference: "A1:C3", Expected: cellRange{FirstColumn: 0, FirstRow: 1, LastColumn: 2, LastRow: 3}},
+	{Reference: "$B$2:$D$10", Expected: cellRange{FirstColumn: 1, FirstRow: 2, LastColumn: 3, LastRow: 10}},
+	{Reference: "C3:A1", Expected: cellRange{FirstColumn: 0, FirstRow: 1, LastColumn: 2, LastRow: 3}},
+	{Reference: "B2", Expected: cellRange{FirstColumn: 1, FirstRow: 2, LastColumn: 1, LastRow: 2}},
+	{Reference: "A1:C", Error: `invalid range "A1:C": invalid cell reference "C"`},
+	{Reference: "A1:ZZZZZZZ1", Error: `invalid range "A1:ZZZZZZZ1": invalid cell reference "ZZZZZZZ1"`},
+}
+
+func TestParsingCellRanges(t *testing.T) {
+	for _, test := range parseCellRangeTests {
+		t.Run(test.Reference, func(t *testing.T) {
+			actual, err := parseCellRange(test.Reference)
+
+			if test.Error != "" {
+				require.EqualError(t, err, test.Error)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, actual)
+		})
+	}
+}
+
+var parseClampedCellRangeTests = []struct {
+	Reference string
+	Expected  cellRange
+	Error     string
+}{
+	{Reference: "A1:C3", Expected: cellRange{FirstColumn: 0, FirstRow: 1, LastColumn: 2, LastRow: 3}},
+	{Reference: "A1:A2000000000", Expected: cellRange{FirstColumn: 0, FirstRow: 1, LastColumn: 0, LastRow: maxRows}},
+	{Reference: "B2:ZZZZZZZ3", Expected: cellRange{FirstColumn: 1, FirstRow: 2, LastColumn: maxColumns - 1, LastRow: 3}},
+	{Reference: "A1:B99999999999999999999", Expected: cellRange{FirstColumn: 0, FirstRow: 1, LastColumn: 1, LastRow: maxRows}},
+	{Reference: "A1048577:A1048578", Error: `invalid range "A1048577:A1048578": range is beyond the sheet`},
+	{Reference: "A1:A-1", Error: `invalid range "A1:A-1": invalid cell reference "A-1"`},
+}
+
+func TestParsingClampedCellRanges(t *testing.T) {
+	for _, test := range parseClampedCellRangeTests {
+		t.Run(test.Reference, func(t *testing.T) {
+			actual, err := parseClampedCellRange(test.Reference)
+
+			if test.Error != "" {
+				require.EqualError(t, err, test.Error)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, actual)
+		})
+	}
+}
+
+func TestCellRangeContains(t *testing.T) {
+	r := cellRange{FirstColumn: 1, FirstRow: 2, LastColumn: 3, LastRow: 4}
+
+	require.True(t, r.contains(1, 2))
+	require.True(t, r.contains(3, 4))
+	require.False(t, r.contains(0, 2))
+	require.False(t, r.contains(1, 5))
+}
+
+func TestCellRangeValid(t *testing.T) {
+	require.True(t, cellRange{FirstColumn: 0, FirstRow: 1, LastColumn: maxColumns - 1, LastRow: maxRows}.valid())
+	require.False(t, cellRange{FirstColumn: 0, FirstRow: 1, LastColumn: maxColumns, LastRow: 1}.valid())
+	require.False(t, cellRange{FirstColumn: 0, FirstRow: 1, LastColumn: 0, LastRow: maxRows + 1}.valid())
+	require.False(t, cellRange{FirstColumn: 0, FirstRow: 0, LastColumn: 0, LastRow: 1}.valid())
+}
+
+var splitSheetReferenceTests = []struct {
+	Reference string
+	Sheet     string
+	Local     string
+}{
+	{Reference: "Sheet1!A1:C3", Sheet: "Sheet1", Local: "A1:C3"},
+	{Reference: "'My Sheet'!$A$1", Sheet: "My Sheet", Local: "$A$1"},
+	{Reference: "'O''Brien''s'!B2", Sheet: "O'Brien's", Local: "B2"},
+	{Reference: "A1", Sheet: "", Local: "A1"},
+}
+
+func TestSplittingSheetReferences(t *testing.T) {
+	for _, test := range splitSheetReferenceTests {
+		t.Run(test.Reference, func(t *testing.T) {
+			sheet, local := splitSheetReference(test.Reference)
+
+			require.Equal(t, test.Sheet, sheet)
+			require.Equal(t, test.Local, local)
+		})
+	}
+}
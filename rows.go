@@ -1,29 +1,55 @@
 package xlsxreader
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
 )
 
 // rawRow represent the raw XML element for parsing a row of data.
 type rawRow struct {
-	Index    int       `xml:"r,attr,omitempty"`
-	RawCells []rawCell `xml:"c"`
+	Index        int       `xml:"r,attr,omitempty"`
+	Hidden       bool      `xml:"hidden,attr"`
+	Height       float64   `xml:"ht,attr"`
+	CustomHeight bool      `xml:"customHeight,attr"`
+	OutlineLevel int       `xml:"outlineLevel,attr"`
+	Collapsed    bool      `xml:"collapsed,attr"`
+	Style        int       `xml:"s,attr"`
+	CustomFormat bool      `xml:"customFormat,attr"`
+	RawCells     []rawCell `xml:"c"`
 }
 
 func (rr *rawRow) unmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	for _, attr := range start.Attr {
-		if attr.Name.Local != "r" {
-			continue
-		}
-
 		var err error
 
-		if rr.Index, err = strconv.Atoi(attr.Value); err != nil {
-			return fmt.Errorf("unable to parse row index: %w", err)
+		switch attr.Name.Local {
+		case "r":
+			if rr.Index, err = strconv.Atoi(attr.Value); err != nil {
+				return fmt.Errorf("unable to parse row index: %w", err)
+			}
+		case "hidden":
+			rr.Hidden, err = strconv.ParseBool(attr.Value)
+		case "ht":
+			rr.Height, err = strconv.ParseFloat(attr.Value, 64)
+		case "customHeight":
+			rr.CustomHeight, err = strconv.ParseBool(attr.Value)
+		case "outlineLevel":
+			rr.OutlineLevel, err = strconv.Atoi(attr.Value)
+		case "collapsed":
+			rr.Collapsed, err = strconv.ParseBool(attr.Value)
+		case "s":
+			rr.Style, err = strconv.Atoi(attr.Value)
+		case "customFormat":
+			rr.CustomFormat, err = strconv.ParseBool(attr.Value)
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to parse row attribute %s: %w", attr.Name.Local, err)
 		}
 	}
 
@@ -61,11 +87,12 @@ func (rr *rawRow) unmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 
 // rawCell represents the raw XML element for parsing a cell.
 type rawCell struct {
-	Reference    string  `xml:"r,attr"` // E.g. A1
-	Type         string  `xml:"t,attr,omitempty"`
-	Value        *string `xml:"v,omitempty"`
-	Style        int     `xml:"s,attr"`
-	InlineString *string `xml:"is>t"`
+	Reference    string      `xml:"r,attr"` // E.g. A1
+	Type         string      `xml:"t,attr,omitempty"`
+	Value        *string     `xml:"v,omitempty"`
+	Style        int         `xml:"s,attr"`
+	InlineString *string     `xml:"is>t"`
+	Formula      *rawFormula `xml:"f"`
 }
 
 func (rc *rawCell) unmarshalXML(d *xml.Decoder, start xml.StartElement) error {
@@ -108,6 +135,9 @@ func (rc *rawCell) unmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		switch se.Name.Local {
 		case "is":
 			err = rc.unmarshalInlineString(d, se)
+		case "f":
+			rc.Formula = &rawFormula{}
+			err = rc.Formula.unmarshalXML(d, se)
 		case "v":
 			var v string
 
@@ -166,6 +196,14 @@ type Row struct {
 	Error error
 	Index int
 	Cells []Cell
+
+	Hidden       bool
+	Height       float64 // In points, or zero if the height is not recorded
+	CustomHeight bool    // Whether the height has been set manually
+	OutlineLevel int     // The depth of the outline group containing the row, or zero
+	Collapsed    bool    // Whether the outline group beneath the row is collapsed
+	Style        int     // Index of the row's style, which applies to its empty cells if CustomFormat is set
+	CustomFormat bool
 }
 
 // Cell represents the data in a single cell as a consumable format.
@@ -174,6 +212,17 @@ type Cell struct {
 	Row    int
 	Value  string
 	Type   CellType
+
+	Formula      string      // E.G   SUM(A1:A3), empty if the value was not calculated
+	FormulaType  FormulaType // Empty if the value was not calculated
+	FormulaRange string      // The cells populated by an array or data table formula, E.G   A1:A3
+
+	Error     error      // Only set for error cells, when they are read as errors rather than values
+	Hyperlink *Hyperlink // Only set when reading with hyperlinks
+	Comment   *Comment   // Only set when reading with comments
+
+	serial   string // The serial number a date cell's value was formatted from, E.G   43489.25
+	date1904 bool   // Whether serial is relative to 1904, rather than 1900
 }
 
 // CellType defines the data type of an excel cell
@@ -188,6 +237,8 @@ const (
 	TypeDateTime CellType = "datetime"
 	// TypeBoolean is for true/false values
 	TypeBoolean CellType = "boolean"
+	// TypeError is for error values, such as #N/A or #DIV/0!
+	TypeError CellType = "error"
 )
 
 // ColumnIndex gives a number, representing the column the cell lies beneath.
@@ -195,6 +246,11 @@ func (c Cell) ColumnIndex() int {
 	return asIndex(c.Column)
 }
 
+// Reference gives the reference of the cell within its sheet, E.G   A1
+func (c Cell) Reference() string {
+	return c.Column + strconv.Itoa(c.Row)
+}
+
 // getCellValue interrogates a raw cell to get a textual representation of the cell's contents.
 // Numerical values are returned in their string format.
 // Dates are returned as an ISO YYYY-MM-DD formatted string.
@@ -224,8 +280,17 @@ func (x *XlsxFile) getCellValue(r rawCell) (string, error) {
 		return x.sharedStrings[index], nil
 	}
 
-	if x.dateStyles[r.Style] && r.Type != "d" {
-		formattedDate, err := convertExcelDateToDateString(*r.Value)
+	if r.Type == "d" {
+		// Dates of strict workbooks are stored as ISO 8601 text
+		formattedDate, _, err := convertISODateToDateString(*r.Value, x.Date1904)
+		if err != nil {
+			return "", err
+		}
+		return formattedDate, nil
+	}
+
+	if x.dateStyles[r.Style] && r.Type != "e" {
+		formattedDate, err := convertExcelDateToDateString(*r.Value, x.Date1904)
 		if err != nil {
 			return "", err
 		}
@@ -236,6 +301,10 @@ func (x *XlsxFile) getCellValue(r rawCell) (string, error) {
 }
 
 func (x *XlsxFile) getCellType(r rawCell) CellType {
+	if r.Type == "e" {
+		return TypeError
+	}
+
 	if x.dateStyles[r.Style] {
 		return TypeDateTime
 	}
@@ -256,44 +325,44 @@ func (x *XlsxFile) getCellType(r rawCell) CellType {
 
 // readSheetRows iterates over "row" elements within a worksheet,
 // pushing a parsed Row struct into a channel for each one.
-func (x *XlsxFile) readSheetRows(sheet string, ch chan<- Row) {
+// Reading stops early if either the file is closed, or the given context is done. In the
+// latter case the context's error is pushed as a final Row.
+func (x *XlsxFile) readSheetRows(ctx context.Context, sheet string, opts Options, ch chan<- Row) {
 	defer close(ch)
 
-	xmlFile, err := x.openSheetFile(sheet)
-	if err != nil {
-		select {
-		case <-x.doneCh:
-		case ch <- Row{Error: err}:
+	for row := range x.all(ctx, sheet, opts) {
+		if x.sendRow(ctx, ch, row) {
+			continue
 		}
-		return
-	}
-	defer xmlFile.Close()
 
-	decoder := xml.NewDecoder(xmlFile)
-	for {
-		token, _ := decoder.Token()
-		if token == nil {
-			return
+		if err := ctx.Err(); err != nil {
+			x.sendRow(context.Background(), ch, Row{Error: err})
 		}
+		return
+	}
+}
 
-		switch startElement := token.(type) {
-		case xml.StartElement:
-			if startElement.Name.Local == "row" {
-				row := x.parseRow(decoder, &startElement)
-				if len(row.Cells) < 1 && row.Error == nil {
-					continue
-				}
-				select {
-				case <-x.doneCh:
-					return
-				case ch <- row:
-				}
-			}
-		}
+// sendRow pushes a row into the channel, unless the file is closed or the context is done
+// first. It reports whether the row was sent.
+func (x *XlsxFile) sendRow(ctx context.Context, ch chan<- Row, row Row) bool {
+	select {
+	case <-x.doneCh:
+		return false
+	case <-ctx.Done():
+		return false
+	case ch <- row:
+		return true
 	}
 }
 
 func (x *XlsxFile) openSheetFile(sheet string) (io.ReadCloser, error) {
+	if x.format != nil {
+		if !slices.Contains(x.Sheets, sheet) {
+			return nil, fmt.Errorf("unable to open sheet %s", sheet)
+		}
+		return nil, fmt.Errorf("unable to read sheet %s of %s workbook: %w", sheet, x.format.name(), ErrNotSupported)
+	}
+
 	file, ok := x.sheetFiles[sheet]
 	if !ok {
 		return nil, fmt.Errorf("unable to open sheet %s", sheet)
@@ -304,37 +373,92 @@ func (x *XlsxFile) openSheetFile(sheet string) (io.ReadCloser, error) {
 // parseRow parses the raw XML of a row element into a consumable Row struct.
 // The Row struct returned will contain any errors that occurred either in
 // interrogating values, or in parsing the XML.
-func (x *XlsxFile) parseRow(decoder *xml.Decoder, startElement *xml.StartElement) Row {
+// If the row element itself cannot be decoded, the error is also returned, as the rest of
+// the sheet cannot then be read.
+// The index of the row read before it is used for a row without an index of its own.
+func (x *XlsxFile) parseRow(decoder *xml.Decoder, startElement *xml.StartElement, formulas sharedFormulas, previous int) (Row, error) {
 	var r rawRow
 	err := r.unmarshalXML(decoder, *startElement)
 	if err != nil {
 		return Row{
 			Error: err,
 			Index: r.Index,
-		}
+		}, err
 	}
 
-	cells, err := x.parseRawCells(r.RawCells, r.Index)
-	if err != nil {
-		return Row{
-			Error: err,
-			Index: r.Index,
+	if err := r.fillReferences(previous); err != nil {
+		return Row{Error: err, Index: r.Index}, nil
+	}
+	return x.newRow(r, formulas), nil
+}
+
+// fillReferences gives the row, and each of its cells, a reference where none is given.
+// The r attributes of rows and cells are optional, in which case, as in Excel, each row or
+// cell follows on from the one before it.
+func (rr *rawRow) fillReferences(previous int) error {
+	if rr.Index == 0 {
+		rr.Index = previous + 1
+	}
+
+	column := -1
+	for i := range rr.RawCells {
+		rc := &rr.RawCells[i]
+		if rc.Reference != "" {
+			var err error
+			if column, _, err = splitCellReference(rc.Reference); err != nil {
+				return fmt.Errorf("unable to parse cell reference: %w", err)
+			}
+			continue
+		}
+
+		column++
+		if column >= maxColumns {
+			return fmt.Errorf("unable to place cell: row %d has cells beyond the last column", rr.Index)
 		}
+		rc.Reference = columnName(column) + strconv.Itoa(rr.Index)
 	}
-	return Row{
-		Cells: cells,
-		Index: r.Index,
+
+	return nil
+}
+
+// newRow converts a raw row into a consumable Row struct, interpreting each of its cells.
+func (x *XlsxFile) newRow(r rawRow, formulas sharedFormulas) Row {
+	row := Row{
+		Index:        r.Index,
+		Hidden:       r.Hidden,
+		Height:       r.Height,
+		CustomHeight: r.CustomHeight,
+		OutlineLevel: r.OutlineLevel,
+		Collapsed:    r.Collapsed,
+		Style:        r.Style,
+		CustomFormat: r.CustomFormat,
 	}
+
+	cells, err := x.parseRawCells(r.RawCells, r.Index, formulas)
+	if err != nil {
+		row.Error = err
+		return row
+	}
+
+	row.Cells = cells
+	return row
 }
 
 // parseRawCells converts a slice of structs containing a raw representation of the XML into
 // a standardised slice of Cell structs. An error will be returned if it is not possible
-// to interpret the value of any of the cells.
-func (x *XlsxFile) parseRawCells(rawCells []rawCell, index int) ([]Cell, error) {
+// to interpret the value or formula of any of the cells.
+// Any shared formulas defined by the cells are added to formulas, for use by later rows.
+func (x *XlsxFile) parseRawCells(rawCells []rawCell, index int, formulas sharedFormulas) ([]Cell, error) {
 	cells := []Cell{}
 	for _, rawCell := range rawCells {
 		if rawCell.Value == nil && rawCell.InlineString == nil {
-			// This cell is empty, so ignore it
+			// This cell is empty, so ignore it, though it may still define a shared formula
+			// used by later cells
+			if f := rawCell.Formula; f != nil && f.Type == "shared" && f.Ref != "" {
+				if _, _, err := formulas.getCellFormula(rawCell); err != nil {
+					return nil, err
+				}
+			}
 			continue
 		}
 		column := strings.Map(removeNonAlpha, rawCell.Reference)
@@ -342,18 +466,62 @@ func (x *XlsxFile) parseRawCells(rawCells []rawCell, index int) ([]Cell, error)
 		if err != nil {
 			return nil, err
 		}
+		formula, formulaType, err := formulas.getCellFormula(rawCell)
+		if err != nil {
+			return nil, err
+		}
 
-		cells = append(cells, Cell{
-			Column: column,
-			Row:    index,
-			Value:  val,
-			Type:   x.getCellType(rawCell),
-		})
+		cell := Cell{
+			Column:      column,
+			Row:         index,
+			Value:       val,
+			Type:        x.getCellType(rawCell),
+			Formula:     formula,
+			FormulaType: formulaType,
+		}
+		if formulaType == FormulaArray || formulaType == FormulaDataTable {
+			cell.FormulaRange = rawCell.Formula.Ref
+		}
+		if cell.Type == TypeDateTime && (rawCell.Type == "n" || rawCell.Type == "") {
+			cell.serial = *rawCell.Value
+			cell.date1904 = x.Date1904
+		}
+		if rawCell.Type == "d" {
+			// The value has already been parsed, so the date is known to be valid
+			_, cell.serial, _ = convertISODateToDateString(*rawCell.Value, x.Date1904)
+			cell.date1904 = x.Date1904
+		}
+
+		cells = append(cells, cell)
 	}
 
 	return cells, nil
 }
 
+// padRow fills the gaps between the cells of a row with empty cells, such that there is a
+// cell for every column from A up to the width, or up to the last cell if that is further.
+func padRow(row Row, width int) Row {
+	if n := len(row.Cells); n > 0 {
+		if last := row.Cells[n-1].ColumnIndex() + 1; last > width {
+			width = last
+		}
+	}
+
+	cells := make([]Cell, 0, width)
+	for _, cell := range row.Cells {
+		for i := len(cells); i < cell.ColumnIndex(); i++ {
+			cells = append(cells, Cell{Column: columnName(i), Row: row.Index, Type: TypeString})
+		}
+		cells = append(cells, cell)
+	}
+	for i := len(cells); i < width; i++ {
+		cells = append(cells, Cell{Column: columnName(i), Row: row.Index, Type: TypeString})
+	}
+
+	row.Cells = cells
+	return row
+}
+
 // ReadRows provides an interface allowing rows from a specific worksheet to be streamed
 // from an xlsx file.
 // In order to provide a simplistic interface, this method returns a channel that can be
@@ -365,10 +533,40 @@ func (x *XlsxFile) parseRawCells(rawCells []rawCell, index int) ([]Cell, error)
 //
 // Notes:
 // Xlsx sheets may omit cells which are empty, meaning a row may not have continuous cell
-// references. This function makes no attempt to fill/pad the missing cells.
+// references. This function makes no attempt to fill/pad the missing cells, see
+// ReadRowsWithOptions and Options.Dense for reading rows with them filled in.
 func (x *XlsxFile) ReadRows(sheet string) chan Row {
+	return x.ReadRowsContext(context.Background(), sheet)
+}
+
+// ReadRowsContext behaves like ReadRows, but additionally stops streaming rows once the
+// given context is cancelled or times out. When that happens, a final Row is sent with
+// its Error set to the context's error, after which the channel is closed.
+//
+// Cancelling the context only affects the stream it was passed to, so it can be used to
+// abandon a single sheet while other sheets of the same file continue to be read. This
+// also makes it possible to stop early when reading from a file without a Close() method,
+// such as those returned by NewReader. The channel should still be drained after
+// cancelling, so that the final error is received and the goroutine can exit.
+func (x *XlsxFile) ReadRowsContext(ctx context.Context, sheet string) chan Row {
 	rowChannel := make(chan Row)
-	go x.readSheetRows(sheet, rowChannel)
+	go x.readSheetRows(ctx, sheet, Options{}, rowChannel)
+	return rowChannel
+}
+
+// ReadRowsWithOptions behaves like ReadRows, but with the rows read according to the options.
+func (x *XlsxFile) ReadRowsWithOptions(sheet string, opts Options) chan Row {
+	rowChannel := make(chan Row)
+	go x.readSheetRows(context.Background(), sheet, opts, rowChannel)
+	return rowChannel
+}
+
+// errorRows returns a closed channel holding a single Row, reporting an error which
+// prevented any rows from being read.
+func errorRows(err error) chan Row {
+	rowChannel := make(chan Row, 1)
+	rowChannel <- Row{Error: err}
+	close(rowChannel)
 	return rowChannel
 }
 
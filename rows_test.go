@@ -1,6 +1,8 @@
 package xlsxreader
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
 
@@ -20,7 +22,9 @@ var (
 	sharedString              = "2"
 	offsetTooHighSharedString = "32"
 	dateString                = "2005-06-04"
+	dateTimeString            = "2005-06-04T06:30:00"
 	boolString                = "1"
+	errorString               = "#N/A"
 )
 
 var cellValueTests = []struct {
@@ -84,11 +88,31 @@ var cellValueTests = []struct {
 		Cell:     rawCell{Type: "d", Style: 1, Value: &dateString},
 		Expected: dateString,
 	},
+	{
+		Name:     "Date type with time",
+		Cell:     rawCell{Type: "d", Value: &dateTimeString},
+		Expected: "2005-06-04T06:30:00Z",
+	},
+	{
+		Name:  "Invalid date type",
+		Cell:  rawCell{Type: "d", Value: &invalidValue},
+		Error: "unable to parse \"wat\" as an ISO 8601 date",
+	},
 	{
 		Name:     "Boolean type",
 		Cell:     rawCell{Type: "b", Value: &boolString},
 		Expected: boolString,
 	},
+	{
+		Name:     "Error type",
+		Cell:     rawCell{Type: "e", Value: &errorString},
+		Expected: errorString,
+	},
+	{
+		Name:     "Error type with date style",
+		Cell:     rawCell{Type: "e", Style: 1, Value: &errorString},
+		Expected: errorString,
+	},
 	{
 		Name:  "No Inline String or Value",
 		Cell:  rawCell{Type: "s", Reference: "C23"},
@@ -152,6 +176,16 @@ var cellTypeTests = []struct {
 		Cell:     rawCell{Type: "b", Value: &boolString},
 		Expected: TypeBoolean,
 	},
+	{
+		Name:     "Error type",
+		Cell:     rawCell{Type: "e", Value: &errorString},
+		Expected: TypeError,
+	},
+	{
+		Name:     "Error type with date style",
+		Cell:     rawCell{Type: "e", Style: 1, Value: &errorString},
+		Expected: TypeError,
+	},
 	{
 		Name:     "No type",
 		Cell:     rawCell{Type: "", Value: &sharedString},
@@ -180,7 +214,7 @@ func TestReadSheetRows(t *testing.T) {
 	for _, test := range readSheetRowsTests {
 		t.Run(test.SheetName, func(t *testing.T) {
 			rowCh := make(chan Row)
-			go testFile.readSheetRows(test.SheetName, rowCh)
+			go testFile.readSheetRows(context.Background(), test.SheetName, Options{}, rowCh)
 
 			row := <-rowCh
 			require.EqualError(t, row.Error, test.Error)
@@ -262,7 +296,7 @@ var parseRawCellsTests = []struct {
 func TestParsingRawCells(t *testing.T) {
 	for _, test := range parseRawCellsTests {
 		t.Run(test.Name, func(t *testing.T) {
-			cells, err := testFile.parseRawCells(test.RawCells, test.Index)
+			cells, err := testFile.parseRawCells(test.RawCells, test.Index, sharedFormulas{})
 
 			if test.Error != "" {
 				require.EqualError(t, err, test.Error)
@@ -285,17 +319,17 @@ func TestReadingFileContents(t *testing.T) {
 	}
 
 	require.Equal(t, []Row{
-		{Index: 1, Cells: []Cell{
+		{Index: 1, Height: 15, Cells: []Cell{
 			{Column: "A", Row: 1, Value: "rec_id", Type: TypeString},
 			{Column: "B", Row: 1, Value: "culture", Type: TypeString},
 			{Column: "C", Row: 1, Value: "sex", Type: TypeString},
 		}},
-		{Index: 2, Cells: []Cell{
+		{Index: 2, Height: 15, Cells: []Cell{
 			{Column: "A", Row: 2, Value: "rec-67374-org", Type: TypeString},
 			{Column: "B", Row: 2, Value: "usa", Type: TypeString},
 			{Column: "C", Row: 2, Value: "f", Type: TypeString},
 		}},
-		{Index: 3, Cells: []Cell{
+		{Index: 3, Height: 15, Cells: []Cell{
 			{Column: "A", Row: 3, Value: "rec-171273-org", Type: TypeString},
 			{Column: "B", Row: 3, Value: "ara", Type: TypeString},
 			{Column: "C", Row: 3, Value: "m", Type: TypeString},
@@ -327,3 +361,235 @@ func TestColumnRefs(t *testing.T) {
 		require.Equal(t, cas.Index, asIndex(cas.Column), "%s: %d", cas.Column, cas.Index)
 	}
 }
+
+func TestReadingRowsWithCancelledContext(t *testing.T) {
+	e, err := OpenFile("test/test-small.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var rows []Row
+	for row := range e.ReadRowsContext(ctx, "datarefinery_groundtruth_400000") {
+		rows = append(rows, row)
+	}
+
+	require.Len(t, rows, 1)
+	require.True(t, errors.Is(rows[0].Error, context.Canceled))
+}
+
+func TestCancellingOneSheetLeavesOthersReading(t *testing.T) {
+	e, err := OpenFile("test/test-multiple-sheets.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := e.ReadRowsContext(ctx, "testSheet1")
+	other := e.ReadRows("testSheet2")
+
+	first := <-cancelled
+	require.NoError(t, first.Error)
+	cancel()
+
+	var last Row
+	for row := range cancelled {
+		last = row
+	}
+	require.True(t, errors.Is(last.Error, context.Canceled))
+
+	var rows []Row
+	for row := range other {
+		require.NoError(t, row.Error)
+		rows = append(rows, row)
+	}
+	require.NotEmpty(t, rows)
+}
+
+func TestReadingRowsDensely(t *testing.T) {
+	e, err := OpenFile("test/test-dense.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var rows []Row
+	for row := range e.ReadRowsWithOptions("Sparse", Options{Dense: true}) {
+		rows = append(rows, row)
+	}
+
+	require.Equal(t, []Row{
+		{Index: 1, Cells: []Cell{
+			{Column: "A", Row: 1, Type: TypeString},
+			{Column: "B", Row: 1, Type: TypeString},
+			{Column: "C", Row: 1, Type: TypeString},
+			{Column: "D", Row: 1, Type: TypeString},
+		}},
+		{Index: 2, Cells: []Cell{
+			{Column: "A", Row: 2, Type: TypeString},
+			{Column: "B", Row: 2, Value: "name", Type: TypeString},
+			{Column: "C", Row: 2, Type: TypeString},
+			{Column: "D", Row: 2, Value: "age", Type: TypeString},
+		}},
+		{Index: 3, Cells: []Cell{
+			{Column: "A", Row: 3, Type: TypeString},
+			{Column: "B", Row: 3, Type: TypeString},
+			{Column: "C", Row: 3, Type: TypeString},
+			{Column: "D", Row: 3, Type: TypeString},
+		}},
+		{Index: 4, Cells: []Cell{
+			{Column: "A", Row: 4, Value: "1", Type: TypeNumerical},
+			{Column: "B", Row: 4, Type: TypeString},
+			{Column: "C", Row: 4, Value: "3", Type: TypeNumerical},
+			{Column: "D", Row: 4, Type: TypeString},
+		}},
+	}, rows)
+}
+
+func TestReadingRowsDenselyWithColumnCount(t *testing.T) {
+	e, err := OpenFile("test/test-dense.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var widths []int
+	for row := range e.ReadRowsWithOptions("Sparse", Options{Dense: true, Columns: 2}) {
+		widths = append(widths, len(row.Cells))
+	}
+	require.Equal(t, []int{2, 4, 2, 3}, widths)
+
+	widths = nil
+	for row := range e.ReadRowsWithOptions("NoDimension", Options{Dense: true}) {
+		widths = append(widths, len(row.Cells))
+	}
+	require.Equal(t, []int{0, 2}, widths)
+}
+
+func TestReadingRowsDenselyWithoutReferences(t *testing.T) {
+	e, err := OpenFile("test/test-dense.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	// Rows and cells without a reference follow on from the one before them
+	var rows []Row
+	for row := range e.ReadRowsWithOptions("Unreferenced", Options{Dense: true}) {
+		rows = append(rows, row)
+	}
+
+	require.Equal(t, []Row{
+		{Index: 1, Cells: []Cell{
+			{Column: "A", Row: 1, Value: "1", Type: TypeNumerical},
+			{Column: "B", Row: 1, Type: TypeString},
+			{Column: "C", Row: 1, Value: "3", Type: TypeNumerical},
+			{Column: "D", Row: 1, Value: "4", Type: TypeNumerical},
+		}},
+		{Index: 2, Cells: []Cell{}},
+		{Index: 3, Cells: []Cell{
+			{Column: "A", Row: 3, Value: "a", Type: TypeString},
+		}},
+		{Index: 4, Cells: []Cell{
+			{Column: "A", Row: 4, Type: TypeString},
+			{Column: "B", Row: 4, Value: "5", Type: TypeNumerical},
+			{Column: "C", Row: 4, Value: "6", Type: TypeNumerical},
+		}},
+	}, rows)
+}
+
+func TestReadingCellBeyondTheLastColumn(t *testing.T) {
+	e, err := OpenFile("test/test-oversized.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	var rows []Row
+	for row := range e.ReadRowsWithOptions("Cells", Options{Dense: true}) {
+		rows = append(rows, row)
+	}
+
+	require.Len(t, rows, 2)
+	require.EqualError(t, rows[0].Error, `unable to parse cell reference: invalid cell reference "ZZZZZZZ1"`)
+	require.NoError(t, rows[1].Error)
+	require.Equal(t, "2", rows[1].Cells[0].Value)
+}
+
+func TestReadingRowsDenselyWithOversizedDimension(t *testing.T) {
+	e, err := OpenFile("test/test-oversized.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	// The dimension extends beyond the last column, so is ignored in favour of the cells
+	var widths []int
+	for row := range e.ReadRowsWithOptions("Dimension", Options{Dense: true}) {
+		require.NoError(t, row.Error)
+		widths = append(widths, len(row.Cells))
+	}
+	require.Equal(t, []int{2}, widths)
+}
+
+var padRowTests = []struct {
+	Name     string
+	Width    int
+	Cells    []Cell
+	Expected []string
+}{
+	{Name: "Empty", Width: 2, Cells: []Cell{}, Expected: []string{"A", "B"}},
+	{Name: "Gaps", Width: 0, Cells: []Cell{{Column: "B"}, {Column: "D"}}, Expected: []string{"A", "B", "C", "D"}},
+	{Name: "Beyond width", Width: 1, Cells: []Cell{{Column: "C"}}, Expected: []string{"A", "B", "C"}},
+}
+
+func TestPaddingRows(t *testing.T) {
+	for _, test := range padRowTests {
+		t.Run(test.Name, func(t *testing.T) {
+			row := padRow(Row{Index: 1, Cells: test.Cells}, test.Width)
+
+			var columns []string
+			for _, cell := range row.Cells {
+				columns = append(columns, cell.Column)
+			}
+			require.Equal(t, test.Expected, columns)
+		})
+	}
+}
+
+func TestReadingRowAttributes(t *testing.T) {
+	e, err := OpenFile("test/test-row-attributes.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	rows := map[int]Row{}
+	for row := range e.ReadRows("Rows") {
+		require.NoError(t, row.Error)
+		row.Cells = nil
+		rows[row.Index] = row
+	}
+
+	require.Equal(t, Row{Index: 1}, rows[1])
+	require.Equal(t, Row{Index: 2, Hidden: true}, rows[2])
+	require.Equal(t, Row{Index: 3, Hidden: true, OutlineLevel: 1}, rows[3])
+	require.Equal(t, Row{Index: 5, Collapsed: true}, rows[5])
+	require.Equal(t, Row{Index: 6, Height: 30, CustomHeight: true, Style: 2, CustomFormat: true}, rows[6])
+}
+
+var skippingRowsTests = []struct {
+	Name     string
+	Options  Options
+	Expected []int
+}{
+	{Name: "All", Options: Options{}, Expected: []int{1, 2, 3, 4, 5, 6, 8}},
+	{Name: "Hidden", Options: Options{SkipHiddenRows: true}, Expected: []int{1, 5, 6, 8}},
+	{Name: "Collapsed", Options: Options{SkipCollapsedRows: true}, Expected: []int{1, 2, 5, 6, 8}},
+	{Name: "Hidden densely", Options: Options{SkipHiddenRows: true, Dense: true}, Expected: []int{1, 5, 6, 8}},
+}
+
+func TestSkippingHiddenRows(t *testing.T) {
+	e, err := OpenFile("test/test-row-attributes.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	for _, test := range skippingRowsTests {
+		t.Run(test.Name, func(t *testing.T) {
+			var indices []int
+			for row := range e.ReadRowsWithOptions("Rows", test.Options) {
+				require.NoError(t, row.Error)
+				indices = append(indices, row.Index)
+			}
+			require.Equal(t, test.Expected, indices)
+		})
+	}
+}
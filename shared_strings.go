@@ -117,11 +117,12 @@ func (sv *sharedStringsValue) Reset() {
 var errNoSharedStrings = errors.New("no shared strings file exists")
 
 // getSharedStringsFile attempts to find and return the zip.File struct associated with the
-// shared strings section of an xlsx file. An error is returned if the sharedStrings file
-// does not exist, or cannot be found.
-func getSharedStringsFile(files []*zip.File) (*zip.File, error) {
+// shared strings section of an xlsx file, which is either the named file, or has one of the
+// conventional names. An error is returned if the sharedStrings file does not exist, or
+// cannot be found.
+func getSharedStringsFile(files []*zip.File, name string) (*zip.File, error) {
 	for _, file := range files {
-		if file.Name == "xl/sharedStrings.xml" || file.Name == "xl/SharedStrings.xml" {
+		if file.Name == name || file.Name == "xl/sharedStrings.xml" || file.Name == "xl/SharedStrings.xml" {
 			return file, nil
 		}
 	}
@@ -131,8 +132,8 @@ func getSharedStringsFile(files []*zip.File) (*zip.File, error) {
 
 // getSharedStrings loads the contents of the shared string file into memory.
 // This serves as a large lookup table of values, so we can efficiently parse rows.
-func getSharedStrings(files []*zip.File) ([]string, error) {
-	ssFile, err := getSharedStringsFile(files)
+func getSharedStrings(files []*zip.File, name string) ([]string, error) {
+	ssFile, err := getSharedStringsFile(files, name)
 	if err != nil && errors.Is(err, errNoSharedStrings) {
 		// Valid to contain no shared strings
 		return []string{}, nil
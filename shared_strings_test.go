@@ -14,14 +14,14 @@ func TestGetSharedStringsFile(t *testing.T) {
 		{FileHeader: zip.FileHeader{Name: "Bob"}},
 	}
 
-	file, err := getSharedStringsFile(zipFiles)
+	file, err := getSharedStringsFile(zipFiles, "")
 
 	require.NoError(t, err)
 	require.Equal(t, zipFiles[1], file)
 }
 
 func TestNoErrorReturnedIfNoSharedStringsFile(t *testing.T) {
-	actual, err := getSharedStrings([]*zip.File{})
+	actual, err := getSharedStrings([]*zip.File{}, "")
 
 	require.NoError(t, err)
 	require.Equal(t, actual, []string{})
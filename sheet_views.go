@@ -0,0 +1,148 @@
+package xlsxreader
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// SheetView describes how a sheet is displayed within a window of the workbook.
+type SheetView struct {
+	FrozenRows    int    // The number of rows frozen at the top of the sheet
+	FrozenColumns int    // The number of columns frozen at the left of the sheet
+	TopLeftCell   string // The cell shown at the top left of the window, E.G   A1
+	ActiveCell    string // The selected cell, E.G   B2
+	Selection     string // The selected cells, E.G   B2:C4 D6
+	Zoom          int    // As a percentage
+	RightToLeft   bool
+	ShowGridLines bool
+	TabSelected   bool
+}
+
+type rawSheetView struct {
+	TabSelected   bool           `xml:"tabSelected,attr"`
+	ShowGridLines *bool          `xml:"showGridLines,attr"`
+	RightToLeft   bool           `xml:"rightToLeft,attr"`
+	ZoomScale     int            `xml:"zoomScale,attr"`
+	TopLeftCell   string         `xml:"topLeftCell,attr"`
+	Pane          *rawPane       `xml:"pane"`
+	Selections    []rawSelection `xml:"selection"`
+}
+
+type rawPane struct {
+	XSplit     float64 `xml:"xSplit,attr"`
+	YSplit     float64 `xml:"ySplit,attr"`
+	ActivePane string  `xml:"activePane,attr"`
+	State      string  `xml:"state,attr"`
+}
+
+type rawSelection struct {
+	Pane       string `xml:"pane,attr"`
+	ActiveCell string `xml:"activeCell,attr"`
+	Sqref      string `xml:"sqref,attr"`
+}
+
+// SheetViews returns the views of a sheet, one for each window the workbook defines.
+// Only the top of the sheet is read, as the views are described before any of the rows.
+func (x *XlsxFile) SheetViews(sheet string) ([]SheetView, error) {
+	xmlFile, err := x.openSheetFile(sheet)
+	if err != nil {
+		return nil, err
+	}
+	defer xmlFile.Close()
+
+	views := []SheetView{}
+	decoder := xml.NewDecoder(xmlFile)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return views, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		startElement, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch startElement.Name.Local {
+		case "sheetData":
+			return views, nil
+		case "sheetView":
+			var raw rawSheetView
+			if err := decoder.DecodeElement(&raw, &startElement); err != nil {
+				return nil, fmt.Errorf("unable to parse sheet view: %w", err)
+			}
+			views = append(views, newSheetView(raw))
+		}
+	}
+}
+
+// newSheetView converts the raw XML of a sheet view into a SheetView.
+func newSheetView(raw rawSheetView) SheetView {
+	view := SheetView{
+		TopLeftCell:   raw.TopLeftCell,
+		Zoom:          raw.ZoomScale,
+		RightToLeft:   raw.RightToLeft,
+		ShowGridLines: raw.ShowGridLines == nil || *raw.ShowGridLines,
+		TabSelected:   raw.TabSelected,
+	}
+	if view.Zoom == 0 {
+		view.Zoom = 100
+	}
+
+	// The selection shown is that of the active pane, which is the top left pane by default
+	activePane := "topLeft"
+	if pane := raw.Pane; pane != nil {
+		if pane.ActivePane != "" {
+			activePane = pane.ActivePane
+		}
+		// The splits of panes which are not frozen are a position, rather than a count
+		if pane.State == "frozen" || pane.State == "frozenSplit" {
+			view.FrozenRows = int(pane.YSplit)
+			view.FrozenColumns = int(pane.XSplit)
+		}
+	}
+
+	for _, selection := range raw.Selections {
+		pane := selection.Pane
+		if pane == "" {
+			pane = "topLeft"
+		}
+		if pane == activePane {
+			view.ActiveCell = selection.ActiveCell
+			view.Selection = selection.Sqref
+		}
+	}
+
+	return view
+}
+
+// HeaderRows gives a hint as to the number of header rows a sheet has, being the number of
+// rows frozen at the top of the sheet's first view, as authors commonly freeze the headers
+// so that they remain visible. Any rows scrolled out of sight above the frozen rows are
+// counted as headers too. Zero is returned if no rows are frozen, in which case the number
+// of header rows is unknown.
+func (x *XlsxFile) HeaderRows(sheet string) (int, error) {
+	views, err := x.SheetViews(sheet)
+	if err != nil {
+		return 0, err
+	}
+	if len(views) == 0 || views[0].FrozenRows == 0 {
+		return 0, nil
+	}
+
+	// The frozen rows are counted from the row at the top of the view, rather than the first
+	// row of the sheet
+	view := views[0]
+	if view.TopLeftCell == "" {
+		return view.FrozenRows, nil
+	}
+	_, top, err := splitCellReference(view.TopLeftCell)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse top left cell of sheet view: %w", err)
+	}
+	return top - 1 + view.FrozenRows, nil
+}
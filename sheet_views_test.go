@@ -0,0 +1,62 @@
+package xlsxreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var sheetViewsTests = []struct {
+	Sheet    string
+	Expected []SheetView
+}{
+	{Sheet: "Frozen", Expected: []SheetView{{
+		FrozenRows: 2, FrozenColumns: 1, ActiveCell: "C5", Selection: "C5:D6", Zoom: 85, ShowGridLines: true, TabSelected: true,
+	}}},
+	{Sheet: "Plain", Expected: []SheetView{{ActiveCell: "B2", Selection: "B2", Zoom: 100, RightToLeft: true}}},
+	{Sheet: "Split", Expected: []SheetView{{Zoom: 100, ShowGridLines: true}}},
+	{Sheet: "NoViews", Expected: []SheetView{}},
+	{Sheet: "Scrolled", Expected: []SheetView{{
+		FrozenRows: 1, TopLeftCell: "A4", ActiveCell: "A5", Selection: "A5", Zoom: 100, ShowGridLines: true,
+	}}},
+}
+
+func TestGettingSheetViews(t *testing.T) {
+	e, err := OpenFile("test/test-sheet-views.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	for _, test := range sheetViewsTests {
+		t.Run(test.Sheet, func(t *testing.T) {
+			views, err := e.SheetViews(test.Sheet)
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, views)
+		})
+	}
+
+	_, err = e.SheetViews("NonExistent")
+	require.EqualError(t, err, "unable to open sheet NonExistent")
+}
+
+func TestGettingHeaderRows(t *testing.T) {
+	e, err := OpenFile("test/test-sheet-views.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	rows, err := e.HeaderRows("Frozen")
+	require.NoError(t, err)
+	require.Equal(t, 2, rows)
+
+	rows, err = e.HeaderRows("Split")
+	require.NoError(t, err)
+	require.Equal(t, 0, rows)
+
+	rows, err = e.HeaderRows("NoViews")
+	require.NoError(t, err)
+	require.Equal(t, 0, rows)
+
+	// The frozen row is the fourth, with the three above it scrolled out of sight
+	rows, err = e.HeaderRows("Scrolled")
+	require.NoError(t, err)
+	require.Equal(t, 4, rows)
+}
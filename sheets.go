@@ -4,18 +4,101 @@ import (
 	"archive/zip"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"path"
 	"strings"
 )
 
 // workbook is a struct representing the data we care about from the workbook.xml file.
 type workbook struct {
-	Sheets []sheet `xml:"sheets>sheet"`
+	Properties   workbookProperties `xml:"workbookPr"`
+	Views        []workbookView     `xml:"bookViews>workbookView"`
+	Sheets       []sheet            `xml:"sheets>sheet"`
+	DefinedNames []rawDefinedName   `xml:"definedNames>definedName"`
+}
+
+// workbookView is a struct representing the workbookView xml element.
+type workbookView struct {
+	ActiveTab int `xml:"activeTab,attr,omitempty"`
+}
+
+// workbookProperties is a struct representing the workbookPr xml element.
+type workbookProperties struct {
+	Date1904 bool `xml:"date1904,attr,omitempty"`
 }
 
 // sheet is a struct representing the sheet xml element.
 type sheet struct {
 	Name           string `xml:"name,attr,omitempty"`
-	RelationshipID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
+	SheetID        int    `xml:"sheetId,attr,omitempty"`
+	State          string `xml:"state,attr,omitempty"`
+	RelationshipID string `xml:"id,attr,omitempty"` // In any namespace, as strict workbooks use their own
+}
+
+// SheetState defines whether a sheet is shown to users of a workbook.
+type SheetState string
+
+const (
+	// SheetVisible is for sheets shown as tabs
+	SheetVisible SheetState = "visible"
+	// SheetHidden is for sheets hidden from the tabs, which users can choose to unhide
+	SheetHidden SheetState = "hidden"
+	// SheetVeryHidden is for sheets which can only be unhidden programmatically
+	SheetVeryHidden SheetState = "veryHidden"
+)
+
+// SheetKind defines the type of content held by a sheet.
+type SheetKind string
+
+const (
+	// KindWorksheet is for sheets holding cells of data
+	KindWorksheet SheetKind = "worksheet"
+	// KindChartsheet is for sheets holding only a chart
+	KindChartsheet SheetKind = "chartsheet"
+	// KindDialogsheet is for sheets holding a legacy Excel 5 dialog
+	KindDialogsheet SheetKind = "dialogsheet"
+	// KindMacrosheet is for sheets holding legacy Excel 4 macros
+	KindMacrosheet SheetKind = "macrosheet"
+)
+
+// SheetInfo describes a sheet within a workbook.
+type SheetInfo struct {
+	Name   string
+	ID     int // The sheetId, which stays the same when sheets are renamed or reordered
+	State  SheetState
+	Kind   SheetKind
+	Active bool // Whether this is the sheet shown when the workbook is opened
+}
+
+// Visible reports whether the sheet is shown as a tab.
+func (s SheetInfo) Visible() bool {
+	return s.State == SheetVisible
+}
+
+// getSheetKind determines the type of a sheet from the type of the relationship to its file.
+func getSheetKind(rel relationship) SheetKind {
+	switch path.Base(rel.Type) {
+	case "chartsheet":
+		return KindChartsheet
+	case "dialogsheet":
+		return KindDialogsheet
+	case "xlMacrosheet", "xlIntlMacrosheet":
+		return KindMacrosheet
+	default:
+		return KindWorksheet
+	}
+}
+
+// VisibleWorksheets returns the names of the worksheets which are shown as tabs, skipping
+// hidden sheets and sheets which do not hold cells, such as chartsheets.
+func (x *XlsxFile) VisibleWorksheets() []string {
+	var names []string
+	for _, s := range x.SheetInfo {
+		if s.Visible() && s.Kind == KindWorksheet {
+			names = append(names, s.Name)
+		}
+	}
+	return names
 }
 
 // relationships is a struct representing the data we care about from the _rels/workboox.xml.rels file.
@@ -24,45 +107,145 @@ type relationships struct {
 }
 
 type relationship struct {
-	ID     string `xml:"Id,attr,omitempty"`
-	Target string `xml:"Target,attr,omitempty"`
+	ID         string `xml:"Id,attr,omitempty"`
+	Target     string `xml:"Target,attr,omitempty"`
+	TargetMode string `xml:"TargetMode,attr,omitempty"`
+	Type       string `xml:"Type,attr,omitempty"`
 }
 
-func getFileNameFromRelationships(rels []relationship, s sheet) (string, error) {
+// getRelationshipsOfType finds the relationships of a given type, identified by the last
+// part of the type's URI, e.g. "comments" for
+// http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments
+// As only the last part is compared, this finds the relationships of both transitional and
+// strict workbooks, whose types use http://purl.oclc.org/ooxml/officeDocument/relationships/
+func getRelationshipsOfType(rels []relationship, typ string) []relationship {
+	var found []relationship
 	for _, rel := range rels {
-		if rel.ID == s.RelationshipID {
-			if strings.HasPrefix(rel.Target, "/") {
-				// path is absolute, take all but the leading slash
-				return rel.Target[1:], nil
-			}
-			// path is relative, so needs xl/ adding
-			return "xl/" + rel.Target, nil
+		if path.Base(rel.Type) == typ {
+			found = append(found, rel)
+		}
+	}
+	return found
+}
+
+// getRelationship finds a relationship by its ID.
+func getRelationship(rels []relationship, id string) (relationship, bool) {
+	for _, rel := range rels {
+		if rel.ID == id {
+			return rel, true
 		}
 	}
-	return "", fmt.Errorf("unable to find file with relationship %s", s.RelationshipID)
+	return relationship{}, false
+}
+
+// getPartRelationships loads the relationships of a part within the archive, e.g. for
+// xl/worksheets/sheet1.xml these are found in xl/worksheets/_rels/sheet1.xml.rels.
+// Parts are not required to have any relationships, so if the file does not exist an
+// empty slice is returned. The relationships of the package itself are loaded for an empty
+// part name.
+func getPartRelationships(files []*zip.File, partName string) ([]relationship, error) {
+	relsFile, err := getFileForName(files, getRelationshipsName(partName))
+	if err != nil {
+		return []relationship{}, nil
+	}
+	data, err := readFile(relsFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read relationships file: %w", err)
+	}
+
+	rels := relationships{}
+	if err := xml.Unmarshal(data, &rels); err != nil {
+		return nil, fmt.Errorf("unable to parse relationships file: %w", err)
+	}
+	return rels.Relationships, nil
+}
+
+// getRelationshipsName gives the name of the file holding the relationships of a part, or of
+// the package itself for an empty part name.
+func getRelationshipsName(partName string) string {
+	if partName == "" {
+		return "_rels/.rels"
+	}
+	return path.Join(path.Dir(partName), "_rels", path.Base(partName)+".rels")
+}
+
+// resolveRelationshipTarget gives the name of the file within the archive which an internal
+// relationship of a part points to. Targets are either absolute, or relative to the part.
+func resolveRelationshipTarget(partName string, rel relationship) string {
+	if strings.HasPrefix(rel.Target, "/") {
+		return rel.Target[1:]
+	}
+	return path.Join(path.Dir(partName), rel.Target)
+}
+
+// getSheetRelationships loads the relationships of a worksheet.
+func (x *XlsxFile) getSheetRelationships(sheet string) ([]relationship, error) {
+	file, ok := x.sheetFiles[sheet]
+	if !ok {
+		return nil, fmt.Errorf("unable to open sheet %s", sheet)
+	}
+	return getPartRelationships(x.files, file.Name)
+}
+
+func getFileNameFromRelationships(rels []relationship, workbookName string, s sheet) (string, error) {
+	rel, ok := getRelationship(rels, s.RelationshipID)
+	if !ok {
+		return "", fmt.Errorf("unable to find file with relationship %s", s.RelationshipID)
+	}
+	return resolveRelationshipTarget(workbookName, rel), nil
+}
+
+// defaultWorkbookName is the conventional name of the workbook part, which is used if the
+// package does not have a relationship to the workbook.
+const defaultWorkbookName = "xl/workbook.xml"
+
+// getWorkbookName finds the name of the workbook part from the relationships of the package.
+func getWorkbookName(files []*zip.File) string {
+	rels, err := getPartRelationships(files, "")
+	if err != nil {
+		return defaultWorkbookName
+	}
+	if found := getRelationshipsOfType(rels, "officeDocument"); len(found) > 0 {
+		return resolveRelationshipTarget("", found[0])
+	}
+	return defaultWorkbookName
+}
+
+// getRelatedPartName finds the name of the part related to a part by a relationship of the
+// given type, or gives the default name if there is no such relationship.
+func getRelatedPartName(rels []relationship, partName string, typ string, defaultName string) string {
+	if found := getRelationshipsOfType(rels, typ); len(found) > 0 {
+		return resolveRelationshipTarget(partName, found[0])
+	}
+	return defaultName
 }
 
-// getWorksheets loads the workbook.xml file and extracts a list of worksheets, along
-// with a map of the canonical worksheet name to a file descriptor.
-// This will return an error if it is not possible to read the workbook.xml file, or
-// if a worksheet without a file is referenced.
-func getWorksheets(files []*zip.File) ([]string, *map[string]*zip.File, error) {
-	wbFile, err := getFileForName(files, "xl/workbook.xml")
+// getWorkbook loads and parses the workbook.xml file.
+// This will return an error if it is not possible to read the workbook.xml file.
+func getWorkbook(files []*zip.File, workbookName string) (*workbook, error) {
+	wbFile, err := getFileForName(files, workbookName)
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to get workbook file: %w", err)
+		return nil, fmt.Errorf("unable to get workbook file: %w", err)
 	}
 	data, err := readFile(wbFile)
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to read workbook file: %w", err)
+		return nil, fmt.Errorf("unable to read workbook file: %w", err)
 	}
 
 	var wb workbook
 	err = xml.Unmarshal(data, &wb)
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to parse workbook file: %w", err)
+		return nil, fmt.Errorf("unable to parse workbook file: %w", err)
 	}
 
-	relsFile, err := getFileForName(files, "xl/_rels/workbook.xml.rels")
+	return &wb, nil
+}
+
+// getWorksheets extracts a list of worksheets from the workbook, along with a map of the
+// canonical worksheet name to a file descriptor, using the workbook's relationships file.
+// This will return an error if a worksheet without a file is referenced.
+func getWorksheets(files []*zip.File, wb *workbook, workbookName string) ([]SheetInfo, *map[string]*zip.File, error) {
+	relsFile, err := getFileForName(files, getRelationshipsName(workbookName))
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to get relationships file: %w", err)
 	}
@@ -77,11 +260,16 @@ func getWorksheets(files []*zip.File) ([]string, *map[string]*zip.File, error) {
 		return nil, nil, fmt.Errorf("unable to parse relationships file: %w", err)
 	}
 
+	activeTab := 0
+	if len(wb.Views) > 0 {
+		activeTab = wb.Views[0].ActiveTab
+	}
+
 	wsFileMap := map[string]*zip.File{}
-	sheetNames := make([]string, len(wb.Sheets))
+	sheetInfo := make([]SheetInfo, len(wb.Sheets))
 
 	for i, sheet := range wb.Sheets {
-		sheetFilename, err := getFileNameFromRelationships(rels.Relationships, sheet)
+		sheetFilename, err := getFileNameFromRelationships(rels.Relationships, workbookName, sheet)
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to get file name from relationships: %w", err)
 		}
@@ -90,9 +278,73 @@ func getWorksheets(files []*zip.File) ([]string, *map[string]*zip.File, error) {
 			return nil, nil, fmt.Errorf("unable to get file for sheet name %s: %w", sheetFilename, err)
 		}
 
+		rel, _ := getRelationship(rels.Relationships, sheet.RelationshipID)
+
 		wsFileMap[sheet.Name] = sheetFile
-		sheetNames[i] = sheet.Name
+		sheetInfo[i] = SheetInfo{
+			Name:   sheet.Name,
+			ID:     sheet.SheetID,
+			State:  SheetVisible,
+			Kind:   getSheetKind(rel),
+			Active: i == activeTab,
+		}
+		if sheet.State != "" {
+			sheetInfo[i].State = SheetState(sheet.State)
+		}
+	}
+
+	return sheetInfo, &wsFileMap, nil
+}
+
+// elementHandler reads an element of a worksheet found by scanSheet. The handler must read
+// the whole of the element, up to and including its end.
+type elementHandler func(d *xml.Decoder, start xml.StartElement) error
+
+// scanSheet reads through the XML of a worksheet, calling the handler for each element found
+// outside of the sheetData element. Handlers are keyed by the local names of the element
+// and its parent, E.G   mergeCells/mergeCell, so that elements of the same name found
+// elsewhere, such as within a custom sheet view, are not mistaken for those of the sheet.
+// The rows within sheetData are skipped without being parsed, making this suitable for
+// reading the parts of a sheet which describe its rows, rather than the rows themselves.
+// Several parts can be read in a single pass, by giving a handler for each.
+func (x *XlsxFile) scanSheet(sheet string, handlers map[string]elementHandler) error {
+	xmlFile, err := x.openSheetFile(sheet)
+	if err != nil {
+		return err
 	}
+	defer xmlFile.Close()
 
-	return sheetNames, &wsFileMap, nil
+	decoder := xml.NewDecoder(xmlFile)
+	parents := []string{""} // The local names of the elements enclosing the next token
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error retrieving xml token: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "sheetData" {
+				if err := decoder.Skip(); err != nil {
+					return fmt.Errorf("unable to skip sheet data: %w", err)
+				}
+				continue
+			}
+
+			if handle, ok := handlers[parents[len(parents)-1]+"/"+t.Name.Local]; ok {
+				if err := handle(decoder, t); err != nil {
+					return err
+				}
+				continue
+			}
+			parents = append(parents, t.Name.Local)
+		case xml.EndElement:
+			if len(parents) > 1 {
+				parents = parents[:len(parents)-1]
+			}
+		}
+	}
 }
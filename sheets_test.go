@@ -12,3 +12,39 @@ func TestGettingWorksheets(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []string{"testSheet1", "testSheet2", "testSheet3"}, e.Sheets)
 }
+
+func TestGettingSheetInfo(t *testing.T) {
+	e, err := OpenFile("./test/test-sheet-info.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	require.Equal(t, []string{"Data", "Summary", "Lookups", "Secrets", "Chart"}, e.Sheets)
+	require.Equal(t, []SheetInfo{
+		{Name: "Data", ID: 3, State: SheetVisible, Kind: KindWorksheet},
+		{Name: "Summary", ID: 1, State: SheetVisible, Kind: KindWorksheet, Active: true},
+		{Name: "Lookups", ID: 5, State: SheetHidden, Kind: KindWorksheet},
+		{Name: "Secrets", ID: 6, State: SheetVeryHidden, Kind: KindWorksheet},
+		{Name: "Chart", ID: 7, State: SheetVisible, Kind: KindChartsheet},
+	}, e.SheetInfo)
+
+	require.Equal(t, []string{"Data", "Summary"}, e.VisibleWorksheets())
+}
+
+var sheetKindTests = []struct {
+	Type     string
+	Expected SheetKind
+}{
+	{"http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet", KindWorksheet},
+	{"http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet", KindChartsheet},
+	{"http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet", KindDialogsheet},
+	{"http://schemas.microsoft.com/office/2006/relationships/xlMacrosheet", KindMacrosheet},
+	{"http://schemas.microsoft.com/office/2006/relationships/xlIntlMacrosheet", KindMacrosheet},
+}
+
+func TestGettingSheetKind(t *testing.T) {
+	for _, test := range sheetKindTests {
+		t.Run(string(test.Expected), func(t *testing.T) {
+			require.Equal(t, test.Expected, getSheetKind(relationship{Type: test.Type}))
+		})
+	}
+}
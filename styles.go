@@ -68,8 +68,8 @@ func getDateStylesFromStyleSheet(ss *styleSheet) *map[int]bool {
 // getDateFormatStyles reads the styles XML, and returns a map of all styles that relate to date
 // fields.
 // If the styles sheet cannot be found, or cannot be read, then an error is returned.
-func getDateFormatStyles(files []*zip.File) (*map[int]bool, error) {
-	stylesFile, err := getFileForName(files, "xl/styles.xml")
+func getDateFormatStyles(files []*zip.File, name string) (*map[int]bool, error) {
+	stylesFile, err := getFileForName(files, name)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get styles file: %w", err)
 	}
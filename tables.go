@@ -0,0 +1,139 @@
+package xlsxreader
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Table represents an Excel table (also known as a list object), a named range of cells
+// with a header row naming each of its columns.
+type Table struct {
+	Name           string // E.G   Table1
+	Sheet          string
+	Ref            string   // The cells of the table, including any header and totals rows, E.G   A1:C10
+	Columns        []string // The names of the columns, as given in the header row
+	HeaderRowCount int
+	TotalsRowCount int
+
+	bounds cellRange
+}
+
+// Header gives the name of the table column a cell lies beneath, or an empty string if the
+// cell lies outside of the table's columns.
+func (t Table) Header(c Cell) string {
+	i := c.ColumnIndex() - t.bounds.FirstColumn
+	if i < 0 || i >= len(t.Columns) {
+		return ""
+	}
+	return t.Columns[i]
+}
+
+// rawTable is a struct representing the data we care about from a table xml file.
+type rawTable struct {
+	Name           string           `xml:"name,attr"`
+	DisplayName    string           `xml:"displayName,attr"`
+	Ref            string           `xml:"ref,attr"`
+	HeaderRowCount *int             `xml:"headerRowCount,attr"`
+	TotalsRowCount int              `xml:"totalsRowCount,attr"`
+	Columns        []rawTableColumn `xml:"tableColumns>tableColumn"`
+}
+
+type rawTableColumn struct {
+	Name string `xml:"name,attr"`
+}
+
+// Tables returns the tables within every sheet of the workbook.
+func (x *XlsxFile) Tables() ([]Table, error) {
+	if x.format != nil {
+		return nil, x.notSupported("tables")
+	}
+
+	var tables []Table
+
+	for _, sheet := range x.Sheets {
+		rels, err := x.getSheetRelationships(sheet)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read tables: %w", err)
+		}
+
+		for _, rel := range getRelationshipsOfType(rels, "table") {
+			var raw rawTable
+			if err := unmarshalFile(x.files, resolveRelationshipTarget(x.sheetFiles[sheet].Name, rel), &raw); err != nil {
+				return nil, fmt.Errorf("unable to read table: %w", err)
+			}
+
+			table, err := newTable(sheet, raw)
+			if err != nil {
+				return nil, err
+			}
+			tables = append(tables, table)
+		}
+	}
+
+	return tables, nil
+}
+
+// newTable converts the raw representation of a table into a consumable format.
+func newTable(sheet string, raw rawTable) (Table, error) {
+	table := Table{
+		Name:           raw.DisplayName,
+		Sheet:          sheet,
+		Ref:            raw.Ref,
+		HeaderRowCount: 1,
+		TotalsRowCount: raw.TotalsRowCount,
+	}
+	if table.Name == "" {
+		table.Name = raw.Name
+	}
+	if raw.HeaderRowCount != nil {
+		table.HeaderRowCount = *raw.HeaderRowCount
+	}
+	for _, column := range raw.Columns {
+		table.Columns = append(table.Columns, column.Name)
+	}
+
+	var err error
+	if table.bounds, err = parseCellRange(raw.Ref); err != nil {
+		return Table{}, fmt.Errorf("unable to parse range of table %s: %w", table.Name, err)
+	}
+
+	return table, nil
+}
+
+// Table finds a table by its name. As in Excel, table names are not case sensitive.
+func (x *XlsxFile) Table(name string) (Table, error) {
+	tables, err := x.Tables()
+	if err != nil {
+		return Table{}, err
+	}
+
+	for _, table := range tables {
+		if strings.EqualFold(table.Name, name) {
+			return table, nil
+		}
+	}
+
+	return Table{}, fmt.Errorf("unable to find table %s", name)
+}
+
+// ReadTable provides an interface allowing the rows of a table to be streamed, in the same
+// way as ReadRows. Only the data rows of the table are read; the header and totals rows are
+// excluded, with the names of the columns available from the table's definition, see Table.
+//
+// If the table cannot be found, a single Row is sent with its Error set.
+func (x *XlsxFile) ReadTable(name string) chan Row {
+	if x.format != nil {
+		return errorRows(x.notSupported("tables"))
+	}
+
+	table, err := x.Table(name)
+	if err != nil {
+		return errorRows(err)
+	}
+
+	data := table.bounds
+	data.FirstRow += table.HeaderRowCount
+	data.LastRow -= table.TotalsRowCount
+
+	return x.ReadRowsWithOptions(table.Sheet, Options{bounds: &data})
+}
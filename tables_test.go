@@ -0,0 +1,89 @@
+package xlsxreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGettingTables(t *testing.T) {
+	e, err := OpenFile("test/test-tables.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	tables, err := e.Tables()
+	require.NoError(t, err)
+	require.Equal(t, []Table{
+		{
+			Name:           "Orders",
+			Sheet:          "Renamed Sheet",
+			Ref:            "B2:D5",
+			Columns:        []string{"Product", "Qty", "Price"},
+			HeaderRowCount: 1,
+			TotalsRowCount: 1,
+			bounds:         cellRange{FirstColumn: 1, FirstRow: 2, LastColumn: 3, LastRow: 5},
+		},
+		{
+			Name:           "Lookup",
+			Sheet:          "Codes",
+			Ref:            "A1:B2",
+			Columns:        []string{"Column1", "Column2"},
+			HeaderRowCount: 0,
+			bounds:         cellRange{FirstColumn: 0, FirstRow: 1, LastColumn: 1, LastRow: 2},
+		},
+	}, tables)
+
+	tables, err = testFile.Tables()
+	require.EqualError(t, err, "unable to read tables: unable to open sheet worksheetOne")
+	require.Nil(t, tables)
+}
+
+func TestReadingTables(t *testing.T) {
+	e, err := OpenFile("test/test-tables.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	table, err := e.Table("orders")
+	require.NoError(t, err)
+
+	var records []map[string]string
+	for row := range e.ReadTable("orders") {
+		require.NoError(t, row.Error)
+
+		record := map[string]string{}
+		for _, cell := range row.Cells {
+			record[table.Header(cell)] = cell.Value
+		}
+		records = append(records, record)
+	}
+
+	require.Equal(t, []map[string]string{
+		{"Product": "Widget", "Qty": "3", "Price": "2.5"},
+		{"Product": "Gadget", "Qty": "1", "Price": "10"},
+	}, records)
+
+	var indexes []int
+	for row := range e.ReadTable("Lookup") {
+		require.NoError(t, row.Error)
+		indexes = append(indexes, row.Index)
+	}
+	require.Equal(t, []int{1, 2}, indexes)
+}
+
+func TestReadingMissingTable(t *testing.T) {
+	e, err := OpenFile("test/test-tables.xlsx")
+	require.NoError(t, err)
+	defer e.Close()
+
+	row := <-e.ReadTable("Missing")
+	require.EqualError(t, row.Error, "unable to find table Missing")
+}
+
+func TestTableHeaders(t *testing.T) {
+	table := Table{Columns: []string{"One", "Two"}, bounds: cellRange{FirstColumn: 1, LastColumn: 2}}
+
+	require.Equal(t, "", table.Header(Cell{Column: "A"}))
+	require.Equal(t, "One", table.Header(Cell{Column: "B"}))
+	require.Equal(t, "Two", table.Header(Cell{Column: "C"}))
+	require.Equal(t, "", table.Header(Cell{Column: "D"}))
+}
@@ -0,0 +1,709 @@
+package xlsxreader
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf16"
+)
+
+// Legacy workbooks (.xls), saved by Excel 97-2003, are stored as a compound file holding a
+// Workbook stream of BIFF8 records. The stream begins with the globals of the workbook,
+// such as its shared strings and styles, followed by the records of each sheet.
+// See https://learn.microsoft.com/en-us/openspecs/office_file_formats/ms-xls
+const legacyWorkbookStream = "Workbook"
+
+// Types of the BIFF8 records which are read.
+const (
+	biffFormula    = 0x0006
+	biffEOF        = 0x000A
+	biffDateMode   = 0x0022
+	biffFilePass   = 0x002F
+	biffContinue   = 0x003C
+	biffWindow1    = 0x003D
+	biffBoundSheet = 0x0085
+	biffMulRK      = 0x00BD
+	biffRString    = 0x00D6
+	biffXF         = 0x00E0
+	biffSST        = 0x00FC
+	biffLabelSST   = 0x00FD
+	biffDimensions = 0x0200
+	biffNumber     = 0x0203
+	biffLabel      = 0x0204
+	biffBoolErr    = 0x0205
+	biffString     = 0x0207
+	biffRow        = 0x0208
+	biffRK         = 0x027E
+	biffFormat     = 0x041E
+	biffBOF        = 0x0809
+	biff8Version   = 0x0600
+)
+
+// biffRecord is a BIFF8 record, along with the data of any CONTINUE records following it.
+// Records longer than 8224 bytes are split across CONTINUE records.
+type biffRecord struct {
+	typ    uint16
+	chunks [][]byte
+	chunk  int // The chunk being read
+	pos    int // The position within the chunk being read
+}
+
+// biffReader reads the records of a BIFF8 stream.
+type biffReader struct {
+	data []byte
+	pos  int
+}
+
+// read reads the next record, returning io.EOF once there are no more records.
+func (b *biffReader) read() (*biffRecord, error) {
+	record, err := b.readOne()
+	if err != nil {
+		return nil, err
+	}
+
+	for b.pos+4 <= len(b.data) && binary.LittleEndian.Uint16(b.data[b.pos:]) == biffContinue {
+		next, err := b.readOne()
+		if err != nil {
+			return nil, err
+		}
+		record.chunks = append(record.chunks, next.chunks[0])
+	}
+	return record, nil
+}
+
+func (b *biffReader) readOne() (*biffRecord, error) {
+	if b.pos >= len(b.data) {
+		return nil, io.EOF
+	}
+	if b.pos+4 > len(b.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	typ := binary.LittleEndian.Uint16(b.data[b.pos:])
+	size := int(binary.LittleEndian.Uint16(b.data[b.pos+2:]))
+	start := b.pos + 4
+	if start+size > len(b.data) {
+		return nil, fmt.Errorf("unable to read record %#x: %w", typ, io.ErrUnexpectedEOF)
+	}
+
+	b.pos = start + size
+	return &biffRecord{typ: typ, chunks: [][]byte{b.data[start : start+size]}}, nil
+}
+
+// next ensures that there is data left to read within the current chunk, moving to the next
+// chunk if needed.
+func (r *biffRecord) next() error {
+	for r.chunk < len(r.chunks) && r.pos >= len(r.chunks[r.chunk]) {
+		r.chunk++
+		r.pos = 0
+	}
+	if r.chunk >= len(r.chunks) {
+		return errTruncatedRecord
+	}
+	return nil
+}
+
+// bytes reads n bytes from the record, which may span several chunks.
+func (r *biffRecord) bytes(n int) ([]byte, error) {
+	b := make([]byte, 0, n)
+	for len(b) < n {
+		if err := r.next(); err != nil {
+			return nil, err
+		}
+		chunk := r.chunks[r.chunk][r.pos:]
+		take := min(n-len(b), len(chunk))
+		b = append(b, chunk[:take]...)
+		r.pos += take
+	}
+	return b, nil
+}
+
+func (r *biffRecord) uint8() (uint8, error) {
+	b, err := r.bytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (r *biffRecord) uint16() (uint16, error) {
+	b, err := r.bytes(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (r *biffRecord) uint32() (uint32, error) {
+	b, err := r.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// data gives the data of the first chunk of the record, which holds all of the fixed fields.
+func (r *biffRecord) data() []byte {
+	return r.chunks[0]
+}
+
+// chars reads count characters, which are either stored in a single byte or as UTF-16
+// depending on highByte. Where the characters continue into another chunk, the chunk starts
+// with a byte of flags, giving whether the remaining characters are stored in a single byte.
+func (r *biffRecord) chars(count int, highByte bool) (string, error) {
+	chars := make([]uint16, 0, count)
+	for len(chars) < count {
+		if r.pos >= len(r.chunks[r.chunk]) {
+			if r.chunk+1 >= len(r.chunks) {
+				return "", errTruncatedRecord
+			}
+			r.chunk++
+			r.pos = 0
+
+			flags, err := r.uint8()
+			if err != nil {
+				return "", err
+			}
+			highByte = flags&0x01 != 0
+		}
+
+		if highByte {
+			c, err := r.uint16()
+			if err != nil {
+				return "", err
+			}
+			chars = append(chars, c)
+		} else {
+			c, err := r.uint8()
+			if err != nil {
+				return "", err
+			}
+			chars = append(chars, uint16(c))
+		}
+	}
+	return string(utf16.Decode(chars)), nil
+}
+
+// unicodeString reads a string prefixed by the number of characters it holds, stored in
+// countSize bytes, and a byte of flags. Rich text runs and phonetic data are skipped.
+func (r *biffRecord) unicodeString(countSize int) (string, error) {
+	var count int
+	if countSize == 1 {
+		c, err := r.uint8()
+		if err != nil {
+			return "", err
+		}
+		count = int(c)
+	} else {
+		c, err := r.uint16()
+		if err != nil {
+			return "", err
+		}
+		count = int(c)
+	}
+
+	flags, err := r.uint8()
+	if err != nil {
+		return "", err
+	}
+
+	runs, extended := 0, 0
+	if flags&0x08 != 0 {
+		n, err := r.uint16()
+		if err != nil {
+			return "", err
+		}
+		runs = int(n)
+	}
+	if flags&0x04 != 0 {
+		n, err := r.uint32()
+		if err != nil {
+			return "", err
+		}
+		extended = int(n)
+	}
+
+	s, err := r.chars(count, flags&0x01 != 0)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := r.bytes(4*runs + extended); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// legacyFormat reads the sheets of legacy workbooks.
+type legacyFormat struct {
+	stream  []byte
+	offsets map[string]int // The position within the stream of each sheet's records
+}
+
+func (legacyFormat) name() string {
+	return "xls"
+}
+
+func (f legacyFormat) openRows(x *XlsxFile, sheet string) (rowSource, error) {
+	offset, ok := f.offsets[sheet]
+	if !ok {
+		return nil, fmt.Errorf("unable to open sheet %s", sheet)
+	}
+
+	return &legacySheet{
+		x:        x,
+		records:  &biffReader{data: f.stream, pos: offset},
+		rows:     map[int]rawRow{},
+		formulas: sharedFormulas{},
+	}, nil
+}
+
+// newLegacyReader reads a workbook stored as a compound file, which is either a legacy
+// workbook, or an encrypted workbook.
+func newLegacyReader(data []byte) (*XlsxFile, error) {
+	if isEncrypted(data) {
+		return nil, ErrEncrypted
+	}
+
+	c, err := openCompoundFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read compound file: %w", err)
+	}
+	stream, err := c.stream(legacyWorkbookStream)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read workbook stream: %w", err)
+	}
+
+	x := XlsxFile{}
+	if err := x.initLegacy(stream); err != nil {
+		return nil, fmt.Errorf("unable to initialise file: %w", err)
+	}
+	return &x, nil
+}
+
+// initLegacy initialises the XlsxFile from the globals of a legacy workbook.
+func (x *XlsxFile) initLegacy(stream []byte) error {
+	records := &biffReader{data: stream}
+	f := legacyFormat{stream: stream, offsets: map[string]int{}}
+	ss := &styleSheet{}
+	activeTab := 0
+
+	bof, err := records.read()
+	if err != nil {
+		return fmt.Errorf("unable to read workbook: %w", err)
+	}
+	if bof.typ != biffBOF || len(bof.data()) < 2 || binary.LittleEndian.Uint16(bof.data()) != biff8Version {
+		return errors.New("unable to read workbook: only Excel 97-2003 workbooks are supported")
+	}
+
+	for {
+		record, err := records.read()
+		if err == io.EOF {
+			return errors.New("unable to read workbook: missing end of workbook globals")
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read workbook: %w", err)
+		}
+
+		switch record.typ {
+		case biffFilePass:
+			return ErrEncrypted
+		case biffDateMode:
+			if len(record.data()) < 2 {
+				return fmt.Errorf("unable to read date mode: %w", errTruncatedRecord)
+			}
+			x.Date1904 = binary.LittleEndian.Uint16(record.data()) == 1
+		case biffWindow1:
+			if len(record.data()) < 12 {
+				return fmt.Errorf("unable to read window: %w", errTruncatedRecord)
+			}
+			activeTab = int(binary.LittleEndian.Uint16(record.data()[10:]))
+		case biffFormat:
+			id, err := record.uint16()
+			if err != nil {
+				return fmt.Errorf("unable to read number format: %w", err)
+			}
+			code, err := record.unicodeString(2)
+			if err != nil {
+				return fmt.Errorf("unable to read number format: %w", err)
+			}
+			ss.NumberFormats = append(ss.NumberFormats, numberFormat{NumberFormatID: int(id), FormatCode: code})
+		case biffXF:
+			// Cells refer to styles by their index amongst all of the XF records
+			if len(record.data()) < 4 {
+				return fmt.Errorf("unable to read style: %w", errTruncatedRecord)
+			}
+			ss.CellStyles = append(ss.CellStyles, cellStyle{NumberFormatID: int(binary.LittleEndian.Uint16(record.data()[2:]))})
+		case biffBoundSheet:
+			info, offset, err := parseBoundSheet(record)
+			if err != nil {
+				return err
+			}
+			if info.Kind == "" {
+				// Visual Basic modules are stored as sheets, but are not shown as such
+				continue
+			}
+			info.ID = len(x.SheetInfo) + 1
+			x.SheetInfo = append(x.SheetInfo, info)
+			f.offsets[info.Name] = offset
+		case biffSST:
+			if x.sharedStrings, err = parseSharedStringTable(record); err != nil {
+				return fmt.Errorf("unable to get shared strings: %w", err)
+			}
+		case biffEOF:
+			x.Sheets = make([]string, len(x.SheetInfo))
+			for i := range x.SheetInfo {
+				x.SheetInfo[i].Active = i == activeTab
+				x.Sheets[i] = x.SheetInfo[i].Name
+			}
+			if x.sharedStrings == nil {
+				x.sharedStrings = []string{}
+			}
+			x.DefinedNames = []DefinedName{}
+			x.dateStyles = *getDateStylesFromStyleSheet(ss)
+			x.format = f
+			x.doneCh = make(chan struct{})
+			return nil
+		}
+	}
+}
+
+// parseBoundSheet parses the record describing a sheet, giving the position of the sheet's
+// records within the workbook stream.
+// The Kind of the sheet is left empty for Visual Basic modules.
+func parseBoundSheet(record *biffRecord) (SheetInfo, int, error) {
+	offset, err := record.uint32()
+	if err != nil {
+		return SheetInfo{}, 0, fmt.Errorf("unable to read sheet: %w", err)
+	}
+	state, err := record.uint8()
+	if err != nil {
+		return SheetInfo{}, 0, fmt.Errorf("unable to read sheet: %w", err)
+	}
+	kind, err := record.uint8()
+	if err != nil {
+		return SheetInfo{}, 0, fmt.Errorf("unable to read sheet: %w", err)
+	}
+	name, err := record.unicodeString(1)
+	if err != nil {
+		return SheetInfo{}, 0, fmt.Errorf("unable to read sheet: %w", err)
+	}
+
+	info := SheetInfo{Name: name, State: SheetVisible}
+	switch state & 0x03 {
+	case 1:
+		info.State = SheetHidden
+	case 2:
+		info.State = SheetVeryHidden
+	}
+	switch kind {
+	case 0:
+		info.Kind = KindWorksheet
+	case 1:
+		info.Kind = KindMacrosheet
+	case 2:
+		info.Kind = KindChartsheet
+	}
+
+	return info, int(offset), nil
+}
+
+// parseSharedStringTable parses the strings of the shared string table, which commonly
+// continues across several records.
+func parseSharedStringTable(record *biffRecord) ([]string, error) {
+	if _, err := record.uint32(); err != nil {
+		return nil, err
+	}
+	unique, err := record.uint32()
+	if err != nil {
+		return nil, err
+	}
+
+	sharedStrings := make([]string, 0, min(int(unique), len(record.data())))
+	for i := 0; i < int(unique); i++ {
+		s, err := record.unicodeString(2)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read string %d: %w", i, err)
+		}
+		sharedStrings = append(sharedStrings, s)
+	}
+	return sharedStrings, nil
+}
+
+// legacySheet reads the rows of a sheet of a legacy workbook.
+// The rows of a sheet are described in blocks, ahead of the cells of those rows, and the
+// cells are given in order. Formulas are stored in a parsed form, so only their calculated
+// values are read.
+type legacySheet struct {
+	x        *XlsxFile
+	records  *biffReader
+	dim      *cellRange
+	rows     map[int]rawRow // The rows described, but not yet read, keyed by index
+	row      *rawRow        // The row whose cells are being read
+	formula  *binaryCell    // A formula cell, whose string value is held by the next record
+	started  bool           // Whether the beginning of the sheet has been read
+	depth    int            // The depth of any nested substreams, such as embedded charts
+	done     bool           // Whether the end of the sheet has been read
+	formulas sharedFormulas
+}
+
+func (s *legacySheet) next() (Row, error) {
+	for !s.done {
+		record, err := s.records.read()
+		if err == io.EOF {
+			return Row{}, fmt.Errorf("unable to read sheet: %w", io.ErrUnexpectedEOF)
+		}
+		if err != nil {
+			return Row{}, fmt.Errorf("unable to read sheet: %w", err)
+		}
+
+		if !s.started {
+			if record.typ != biffBOF {
+				return Row{}, errors.New("unable to read sheet: missing beginning of sheet")
+			}
+			s.started = true
+			continue
+		}
+
+		switch {
+		case record.typ == biffBOF:
+			s.depth++
+			continue
+		case s.depth > 0:
+			// The records of nested substreams do not describe the sheet
+			if record.typ == biffEOF {
+				s.depth--
+			}
+			continue
+		}
+
+		switch record.typ {
+		case biffEOF:
+			return s.flush()
+		case biffDimensions:
+			if len(record.data()) < 12 {
+				return Row{}, fmt.Errorf("unable to read dimension: %w", errTruncatedRecord)
+			}
+			le := binary.LittleEndian
+			d := record.data()
+			// The last row and column are given exclusively
+			s.dim = &cellRange{
+				FirstRow:    int(le.Uint32(d)) + 1,
+				LastRow:     int(le.Uint32(d[4:])),
+				FirstColumn: int(le.Uint16(d[8:])),
+				LastColumn:  int(le.Uint16(d[10:])) - 1,
+			}
+		case biffRow:
+			row, err := parseLegacyRow(record.data())
+			if err != nil {
+				return Row{}, err
+			}
+			s.rows[row.Index] = row
+		case biffString:
+			if s.formula == nil || s.row == nil {
+				continue
+			}
+			value, err := record.unicodeString(2)
+			if err != nil {
+				return Row{}, fmt.Errorf("unable to read formula string: %w", err)
+			}
+			s.formula.Value = &value
+			s.row.RawCells = append(s.row.RawCells, s.formula.rawCell)
+			s.formula = nil
+		default:
+			index, cells, err := parseLegacyCells(record)
+			if err != nil {
+				return Row{}, err
+			}
+			if len(cells) == 0 {
+				continue
+			}
+
+			// The cells of a record all belong to the same row
+			previous, ended := s.startRow(index)
+			for _, cell := range cells {
+				if cell.Type == "str" && cell.Value == nil {
+					s.formula = &cell
+					continue
+				}
+				s.row.RawCells = append(s.row.RawCells, cell.rawCell)
+			}
+			if ended {
+				return previous, nil
+			}
+		}
+	}
+
+	return Row{}, io.EOF
+}
+
+// startRow begins reading the cells of the row at the given index, unless they are already
+// being read. If the cells of another row were being read, that row has ended, and is returned.
+func (s *legacySheet) startRow(index int) (Row, bool) {
+	if s.row != nil && s.row.Index == index {
+		return Row{}, false
+	}
+
+	previous := s.row
+	row, ok := s.rows[index]
+	if ok {
+		delete(s.rows, index)
+	} else {
+		row = rawRow{Index: index}
+	}
+	s.row = &row
+
+	if previous == nil {
+		return Row{}, false
+	}
+	return s.x.newRow(*previous, s.formulas), true
+}
+
+// flush returns the row being read, if any, once the end of the sheet has been read.
+func (s *legacySheet) flush() (Row, error) {
+	s.done = true
+	if s.row == nil {
+		return Row{}, io.EOF
+	}
+
+	row := *s.row
+	s.row = nil
+	return s.x.newRow(row, s.formulas), nil
+}
+
+func (s *legacySheet) dimension() *cellRange {
+	return s.dim
+}
+
+func (s *legacySheet) Close() error {
+	return nil
+}
+
+// parseLegacyRow parses the record describing a row.
+func parseLegacyRow(data []byte) (rawRow, error) {
+	if len(data) < 16 {
+		return rawRow{}, fmt.Errorf("unable to read row: %w", errTruncatedRecord)
+	}
+
+	le := binary.LittleEndian
+	flags := le.Uint16(data[12:])
+	row := rawRow{
+		Index:        int(le.Uint16(data)) + 1,
+		Height:       float64(le.Uint16(data[6:])&0x7FFF) / 20, // Stored in twentieths of a point
+		OutlineLevel: int(flags & 0x07),
+		Collapsed:    flags&0x10 != 0,
+		Hidden:       flags&0x20 != 0,
+		CustomHeight: flags&0x40 != 0,
+		CustomFormat: flags&0x80 != 0,
+	}
+	if row.CustomFormat {
+		// The style of a row is only applied when it has been formatted
+		row.Style = int(le.Uint16(data[14:]) & 0x0FFF)
+	}
+	return row, nil
+}
+
+// parseLegacyCells parses the cells held by a record into the same form as cells read from
+// XML, so that their values are interpreted in the same way. It gives the index of the row
+// the cells belong to, and no cells for records which do not hold cells.
+// The value of a formula which calculates a string is held by the following record, so it
+// is left unset.
+func parseLegacyCells(record *biffRecord) (int, []binaryCell, error) {
+	switch record.typ {
+	case biffLabelSST, biffNumber, biffRK, biffMulRK, biffBoolErr, biffFormula, biffLabel, biffRString:
+	default:
+		return 0, nil, nil
+	}
+
+	// Each record starts with the row and column of its first cell
+	data := record.data()
+	if len(data) < 6 {
+		return 0, nil, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+	}
+	le := binary.LittleEndian
+	index := int(le.Uint16(data)) + 1
+	newCell := func(column int, style uint16, typ string, value *string) binaryCell {
+		c := binaryCell{column: column}
+		c.Reference = columnName(column) + strconv.Itoa(index)
+		c.Style = int(style)
+		c.Type = typ
+		c.Value = value
+		return c
+	}
+	column, style := int(le.Uint16(data[2:])), le.Uint16(data[4:])
+
+	var value string
+	switch record.typ {
+	case biffMulRK:
+		// Holds a style and RK number for each of a run of cells, followed by the last column
+		cells := []binaryCell{}
+		for i := 4; i+6 <= len(data)-2; i += 6 {
+			v := formatNumber(decodeRK(le.Uint32(data[i+2:])))
+			cells = append(cells, newCell(column, le.Uint16(data[i:]), "n", &v))
+			column++
+		}
+		return index, cells, nil
+	case biffLabelSST:
+		if len(data) < 10 {
+			return 0, nil, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+		}
+		value = strconv.FormatUint(uint64(le.Uint32(data[6:])), 10)
+		return index, []binaryCell{newCell(column, style, "s", &value)}, nil
+	case biffNumber:
+		if len(data) < 14 {
+			return 0, nil, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+		}
+		value = formatNumber(math.Float64frombits(le.Uint64(data[6:])))
+		return index, []binaryCell{newCell(column, style, "n", &value)}, nil
+	case biffRK:
+		if len(data) < 10 {
+			return 0, nil, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+		}
+		value = formatNumber(decodeRK(le.Uint32(data[6:])))
+		return index, []binaryCell{newCell(column, style, "n", &value)}, nil
+	case biffBoolErr:
+		if len(data) < 8 {
+			return 0, nil, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+		}
+		if data[7] != 0 {
+			value = string(getErrorFromCode(data[6]))
+			return index, []binaryCell{newCell(column, style, "e", &value)}, nil
+		}
+		value = strconv.Itoa(int(data[6]))
+		return index, []binaryCell{newCell(column, style, "b", &value)}, nil
+	case biffFormula:
+		if len(data) < 14 {
+			return 0, nil, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+		}
+		result := data[6:14]
+		if le.Uint16(result[6:]) != 0xFFFF {
+			value = formatNumber(math.Float64frombits(le.Uint64(result)))
+			return index, []binaryCell{newCell(column, style, "n", &value)}, nil
+		}
+		switch result[0] {
+		case 0:
+			return index, []binaryCell{newCell(column, style, "str", nil)}, nil
+		case 1:
+			value = strconv.Itoa(int(result[2]))
+			return index, []binaryCell{newCell(column, style, "b", &value)}, nil
+		case 2:
+			value = string(getErrorFromCode(result[2]))
+			return index, []binaryCell{newCell(column, style, "e", &value)}, nil
+		default:
+			return index, []binaryCell{newCell(column, style, "str", &value)}, nil
+		}
+	default:
+		// Labels hold their string after the style
+		if _, err := record.bytes(6); err != nil {
+			return 0, nil, fmt.Errorf("unable to read cell: %w", err)
+		}
+		s, err := record.unicodeString(2)
+		if err != nil {
+			return 0, nil, fmt.Errorf("unable to read cell: %w", err)
+		}
+		return index, []binaryCell{newCell(column, style, "str", &s)}, nil
+	}
+}
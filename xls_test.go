@@ -0,0 +1,127 @@
+package xlsxreader
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadingLegacyWorkbookFromBytes(t *testing.T) {
+	data, err := os.ReadFile("test/test-legacy.xls")
+	require.NoError(t, err)
+
+	e, err := NewReader(data)
+	require.NoError(t, err)
+
+	rows := readAllRows(t, e, "Values")
+	require.Len(t, rows, 5)
+	require.Equal(t, "calculated", rows[4].Cells[1].Value)
+
+	e, err = NewReaderWithPassword(data, "password")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Values", "Empty"}, e.Sheets)
+}
+
+func TestReadingInvalidLegacyWorkbook(t *testing.T) {
+	_, err := NewReader([]byte("not a workbook"))
+	require.Error(t, err)
+}
+
+// biffRecordBytes gives the bytes of a BIFF8 record of the given type.
+func biffRecordBytes(typ uint16, data ...byte) []byte {
+	record := binary.LittleEndian.AppendUint16(nil, typ)
+	record = binary.LittleEndian.AppendUint16(record, uint16(len(data)))
+	return append(record, data...)
+}
+
+// sstHeader is the start of a shared string table holding n strings.
+func sstHeader(n byte) []byte {
+	return []byte{n, 0, 0, 0, n, 0, 0, 0}
+}
+
+// utf16Bytes gives the UTF-16 encoding of s.
+func utf16Bytes(s string) []byte {
+	var b []byte
+	for _, c := range utf16.Encode([]rune(s)) {
+		b = binary.LittleEndian.AppendUint16(b, c)
+	}
+	return b
+}
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, part := range parts {
+		b = append(b, part...)
+	}
+	return b
+}
+
+var sharedStringTableTests = []struct {
+	Name     string
+	Records  []byte
+	Expected []string
+}{
+	{
+		Name: "Within one record",
+		Records: biffRecordBytes(biffSST, concat(sstHeader(2),
+			[]byte{2, 0, 0}, []byte("ab"),
+			[]byte{1, 0, 1}, utf16Bytes("日"))...),
+		Expected: []string{"ab", "日"},
+	},
+	{
+		Name: "Compressed continuing as UTF-16",
+		Records: concat(
+			biffRecordBytes(biffSST, concat(sstHeader(1), []byte{5, 0, 0}, []byte("va"))...),
+			biffRecordBytes(biffContinue, concat([]byte{1}, utf16Bytes("lue"))...),
+		),
+		Expected: []string{"value"},
+	},
+	{
+		Name: "UTF-16 continuing as compressed",
+		Records: concat(
+			biffRecordBytes(biffSST, concat(sstHeader(1), []byte{3, 0, 1}, utf16Bytes("日"))...),
+			biffRecordBytes(biffContinue, concat([]byte{0}, []byte("ab"))...),
+		),
+		Expected: []string{"日ab"},
+	},
+	{
+		Name: "Continuing between strings",
+		Records: concat(
+			biffRecordBytes(biffSST, concat(sstHeader(2), []byte{2, 0, 0}, []byte("ab"))...),
+			biffRecordBytes(biffContinue, concat([]byte{1, 0, 1}, utf16Bytes("語"))...),
+		),
+		Expected: []string{"ab", "語"},
+	},
+	{
+		Name: "Rich text runs continuing",
+		Records: concat(
+			biffRecordBytes(biffSST, concat(sstHeader(2), []byte{2, 0, 0x08, 1, 0}, []byte("ab"), []byte{0, 0})...),
+			biffRecordBytes(biffContinue, concat([]byte{1, 0}, []byte{1, 0, 0}, []byte("c"))...),
+		),
+		Expected: []string{"ab", "c"},
+	},
+}
+
+func TestParsingSharedStringTable(t *testing.T) {
+	for _, test := range sharedStringTableTests {
+		t.Run(test.Name, func(t *testing.T) {
+			records := &biffReader{data: test.Records}
+			record, err := records.read()
+			require.NoError(t, err)
+
+			sharedStrings, err := parseSharedStringTable(record)
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, sharedStrings)
+		})
+	}
+
+	// The string claims more characters than the records hold
+	records := &biffReader{data: biffRecordBytes(biffSST, concat(sstHeader(1), []byte{5, 0, 0}, []byte("va"))...)}
+	record, err := records.read()
+	require.NoError(t, err)
+	_, err = parseSharedStringTable(record)
+	require.Error(t, err)
+}
@@ -0,0 +1,591 @@
+package xlsxreader
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf16"
+)
+
+// Binary workbooks (.xlsb) are stored in the same zip container as XLSX files, but their
+// parts are stored as BIFF12 records, rather than as XML.
+// See https://learn.microsoft.com/en-us/openspecs/office_file_formats/ms-xlsb
+const (
+	binaryWorkbookName      = "xl/workbook.bin"
+	binarySharedStringsName = "xl/sharedStrings.bin"
+	binaryStylesName        = "xl/styles.bin"
+)
+
+// Types of the BIFF12 records which are read.
+const (
+	brtRowHdr          = 0
+	brtCellBlank       = 1
+	brtCellRk          = 2
+	brtCellError       = 3
+	brtCellBool        = 4
+	brtCellReal        = 5
+	brtCellSt          = 6
+	brtCellIsst        = 7
+	brtFmlaString      = 8
+	brtFmlaNum         = 9
+	brtFmlaBool        = 10
+	brtFmlaError       = 11
+	brtSSTItem         = 19
+	brtFmt             = 44
+	brtXF              = 47
+	brtCellRString     = 62
+	brtBookView        = 135
+	brtBeginSheetData  = 145
+	brtEndSheetData    = 146
+	brtWsDim           = 148
+	brtWbProp          = 153
+	brtBundleSh        = 156
+	brtBeginCellXFs    = 617
+	brtEndCellXFs      = 618
+	maxBIFF12TypeBytes = 2
+	maxBIFF12SizeBytes = 4
+)
+
+// errTruncatedRecord indicates that a record is too short to hold the fields it should.
+var errTruncatedRecord = errors.New("record is truncated")
+
+// biff12Reader reads the records of a BIFF12 part.
+type biff12Reader struct {
+	r         *bufio.Reader
+	remaining int64 // The number of bytes of the part which are yet to be read
+}
+
+func newBIFF12Reader(r io.Reader, size int64) *biff12Reader {
+	return &biff12Reader{r: bufio.NewReader(r), remaining: size}
+}
+
+// read reads the type and data of the next record. It returns io.EOF once there are no
+// more records.
+func (b *biff12Reader) read() (int, []byte, error) {
+	typ, err := b.readVarint(maxBIFF12TypeBytes)
+	if err != nil {
+		return 0, nil, err
+	}
+	size, err := b.readVarint(maxBIFF12SizeBytes)
+	if err == io.EOF {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// The size is checked before allocating, as a corrupt size could otherwise be far larger
+	// than the part itself
+	if int64(size) > b.remaining {
+		return 0, nil, fmt.Errorf("unable to read record %d: size %d exceeds the rest of the part", typ, size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(b.r, data); err != nil {
+		return 0, nil, fmt.Errorf("unable to read record %d: %w", typ, err)
+	}
+	b.remaining -= int64(size)
+	return typ, data, nil
+}
+
+// readVarint reads a number stored in up to n bytes, seven bits at a time, with the high bit
+// of each byte indicating whether another byte follows.
+func (b *biff12Reader) readVarint(n int) (int, error) {
+	value := 0
+	for i := 0; i < n; i++ {
+		c, err := b.r.ReadByte()
+		if err == io.EOF && i > 0 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		b.remaining--
+
+		value |= int(c&0x7F) << (7 * i)
+		if c&0x80 == 0 {
+			break
+		}
+	}
+	return value, nil
+}
+
+// readBIFF12Part reads each of the records of a part of a binary workbook.
+func readBIFF12Part(files []*zip.File, name string, handle func(typ int, data []byte) error) error {
+	file, err := getFileForName(files, name)
+	if err != nil {
+		return err
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("unable to open file %s: %w", name, err)
+	}
+	defer rc.Close()
+
+	records := newBIFF12Reader(rc, int64(file.UncompressedSize64))
+	for {
+		typ, data, err := records.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read file %s: %w", name, err)
+		}
+		if err := handle(typ, data); err != nil {
+			return fmt.Errorf("unable to parse file %s: %w", name, err)
+		}
+	}
+}
+
+// readWideString reads a string stored as a count of characters, followed by the UTF-16
+// characters themselves. It returns the string, and the number of bytes read.
+// A count of 0xFFFFFFFF indicates a null string, which is read as an empty string.
+func readWideString(data []byte) (string, int, error) {
+	if len(data) < 4 {
+		return "", 0, errTruncatedRecord
+	}
+
+	count := binary.LittleEndian.Uint32(data)
+	if count == 0xFFFFFFFF {
+		return "", 4, nil
+	}
+	if uint64(len(data)-4) < 2*uint64(count) {
+		return "", 0, errTruncatedRecord
+	}
+
+	chars := make([]uint16, count)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(data[4+2*i:])
+	}
+	return string(utf16.Decode(chars)), 4 + 2*int(count), nil
+}
+
+// initBinary initialises the XlsxFile from the parts of a binary workbook.
+func (x *XlsxFile) initBinary(files []*zip.File) error {
+	sharedStrings, err := getBinarySharedStrings(files)
+	if err != nil {
+		return fmt.Errorf("unable to get shared strings: %w", err)
+	}
+
+	wb, err := getBinaryWorkbook(files)
+	if err != nil {
+		return fmt.Errorf("unable to get workbook: %w", err)
+	}
+
+	sheetInfo, sheetFiles, err := getWorksheets(files, wb, binaryWorkbookName)
+	if err != nil {
+		return fmt.Errorf("unable to get worksheets: %w", err)
+	}
+
+	ss, err := getBinaryStyleSheet(files)
+	if err != nil {
+		return fmt.Errorf("unable to get date styles: %w", err)
+	}
+
+	x.files = files
+	x.workbookName = binaryWorkbookName
+	x.sharedStrings = sharedStrings
+	x.Sheets = make([]string, len(sheetInfo))
+	for i, s := range sheetInfo {
+		x.Sheets[i] = s.Name
+	}
+	x.SheetInfo = sheetInfo
+	x.Date1904 = wb.Properties.Date1904
+	x.DefinedNames = []DefinedName{}
+	x.sheetFiles = *sheetFiles
+	x.dateStyles = *getDateStylesFromStyleSheet(ss)
+	x.format = binaryFormat{}
+	x.doneCh = make(chan struct{})
+
+	return nil
+}
+
+// getBinaryWorkbook reads the properties and sheets of a binary workbook.
+// Defined names are not read, as their formulas are stored in a parsed form.
+func getBinaryWorkbook(files []*zip.File) (*workbook, error) {
+	wb := &workbook{}
+
+	err := readBIFF12Part(files, binaryWorkbookName, func(typ int, data []byte) error {
+		switch typ {
+		case brtWbProp:
+			if len(data) < 4 {
+				return errTruncatedRecord
+			}
+			wb.Properties.Date1904 = binary.LittleEndian.Uint32(data)&0x01 != 0
+		case brtBookView:
+			if len(data) < 28 {
+				return errTruncatedRecord
+			}
+			wb.Views = append(wb.Views, workbookView{ActiveTab: int(binary.LittleEndian.Uint32(data[24:]))})
+		case brtBundleSh:
+			if len(data) < 8 {
+				return errTruncatedRecord
+			}
+			s := sheet{SheetID: int(binary.LittleEndian.Uint32(data[4:]))}
+			switch binary.LittleEndian.Uint32(data) {
+			case 1:
+				s.State = string(SheetHidden)
+			case 2:
+				s.State = string(SheetVeryHidden)
+			}
+
+			relID, n, err := readWideString(data[8:])
+			if err != nil {
+				return err
+			}
+			if s.Name, _, err = readWideString(data[8+n:]); err != nil {
+				return err
+			}
+			s.RelationshipID = relID
+			wb.Sheets = append(wb.Sheets, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wb, nil
+}
+
+// getBinarySharedStrings reads the shared strings of a binary workbook, of which there may
+// be none.
+func getBinarySharedStrings(files []*zip.File) ([]string, error) {
+	sharedStrings := []string{}
+	if _, err := getFileForName(files, binarySharedStringsName); err != nil {
+		return sharedStrings, nil
+	}
+
+	err := readBIFF12Part(files, binarySharedStringsName, func(typ int, data []byte) error {
+		if typ != brtSSTItem {
+			return nil
+		}
+		if len(data) < 1 {
+			return errTruncatedRecord
+		}
+
+		// The string follows a byte of flags, and is itself followed by any formatting runs
+		s, _, err := readWideString(data[1:])
+		if err != nil {
+			return err
+		}
+		sharedStrings = append(sharedStrings, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sharedStrings, nil
+}
+
+// getBinaryStyleSheet reads the number formats of the cell styles of a binary workbook.
+func getBinaryStyleSheet(files []*zip.File) (*styleSheet, error) {
+	ss := &styleSheet{}
+	inCellStyles := false
+
+	err := readBIFF12Part(files, binaryStylesName, func(typ int, data []byte) error {
+		switch typ {
+		case brtFmt:
+			if len(data) < 2 {
+				return errTruncatedRecord
+			}
+			code, _, err := readWideString(data[2:])
+			if err != nil {
+				return err
+			}
+			ss.NumberFormats = append(ss.NumberFormats, numberFormat{
+				NumberFormatID: int(binary.LittleEndian.Uint16(data)),
+				FormatCode:     code,
+			})
+		case brtBeginCellXFs:
+			inCellStyles = true
+		case brtEndCellXFs:
+			inCellStyles = false
+		case brtXF:
+			// Styles are also defined for named cell styles, which cells do not refer to
+			if !inCellStyles {
+				return nil
+			}
+			if len(data) < 4 {
+				return errTruncatedRecord
+			}
+			ss.CellStyles = append(ss.CellStyles, cellStyle{NumberFormatID: int(binary.LittleEndian.Uint16(data[2:]))})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ss, nil
+}
+
+// binaryFormat reads the sheets of binary workbooks.
+type binaryFormat struct{}
+
+func (binaryFormat) name() string {
+	return "xlsb"
+}
+
+func (binaryFormat) openRows(x *XlsxFile, sheet string) (rowSource, error) {
+	file, ok := x.sheetFiles[sheet]
+	if !ok {
+		return nil, fmt.Errorf("unable to open sheet %s", sheet)
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &binarySheet{x: x, file: rc, records: newBIFF12Reader(rc, int64(file.UncompressedSize64)), formulas: sharedFormulas{}}, nil
+}
+
+// binarySheet reads the rows of a sheet of a binary workbook.
+// Formulas are stored in a parsed form, so only their calculated values are read.
+type binarySheet struct {
+	x        *XlsxFile
+	file     io.ReadCloser
+	records  *biff12Reader
+	dim      *cellRange
+	row      *rawRow // The row whose cells are being read
+	inData   bool    // Whether the records being read are within the sheet's data
+	done     bool    // Whether the end of the sheet's data has been read
+	formulas sharedFormulas
+}
+
+func (s *binarySheet) next() (Row, error) {
+	for !s.done {
+		typ, data, err := s.records.read()
+		if err == io.EOF {
+			return s.flush()
+		}
+		if err != nil {
+			return Row{}, fmt.Errorf("unable to read sheet: %w", err)
+		}
+
+		switch {
+		case typ == brtWsDim:
+			if len(data) < 16 {
+				return Row{}, fmt.Errorf("unable to read dimension: %w", errTruncatedRecord)
+			}
+			le := binary.LittleEndian
+			s.dim = &cellRange{
+				FirstRow:    int(le.Uint32(data)) + 1,
+				LastRow:     int(le.Uint32(data[4:])) + 1,
+				FirstColumn: int(le.Uint32(data[8:])),
+				LastColumn:  int(le.Uint32(data[12:])),
+			}
+		case typ == brtBeginSheetData:
+			s.inData = true
+		case typ == brtEndSheetData:
+			s.inData = false
+			return s.flush()
+		case !s.inData:
+			continue
+		case typ == brtRowHdr:
+			row, err := parseBinaryRow(data)
+			if err != nil {
+				return Row{}, err
+			}
+
+			previous := s.row
+			s.row = &row
+			if previous != nil {
+				return s.x.newRow(*previous, s.formulas), nil
+			}
+		default:
+			cell, ok, err := parseBinaryCell(typ, data)
+			if err != nil {
+				return Row{}, err
+			}
+			if !ok {
+				continue
+			}
+			if s.row == nil {
+				return Row{}, errors.New("unable to read cell: cell is outside of a row")
+			}
+
+			cell.Reference = columnName(cell.column) + strconv.Itoa(s.row.Index)
+			s.row.RawCells = append(s.row.RawCells, cell.rawCell)
+		}
+	}
+
+	return Row{}, io.EOF
+}
+
+// flush returns the row being read, if any, once the end of the sheet's data is reached.
+func (s *binarySheet) flush() (Row, error) {
+	s.done = true
+	if s.row == nil {
+		return Row{}, io.EOF
+	}
+
+	row := *s.row
+	s.row = nil
+	return s.x.newRow(row, s.formulas), nil
+}
+
+func (s *binarySheet) dimension() *cellRange {
+	return s.dim
+}
+
+func (s *binarySheet) Close() error {
+	return s.file.Close()
+}
+
+// parseBinaryRow parses the header of a row, which precedes the records of its cells.
+func parseBinaryRow(data []byte) (rawRow, error) {
+	if len(data) < 12 {
+		return rawRow{}, fmt.Errorf("unable to read row: %w", errTruncatedRecord)
+	}
+
+	le := binary.LittleEndian
+	flags := le.Uint16(data[10:])
+	return rawRow{
+		Index:        int(le.Uint32(data)) + 1,
+		Style:        int(le.Uint32(data[4:])),
+		Height:       float64(le.Uint16(data[8:])) / 20, // Stored in twentieths of a point
+		OutlineLevel: int(flags>>8) & 0x07,
+		Collapsed:    flags&0x0800 != 0,
+		Hidden:       flags&0x1000 != 0,
+		CustomHeight: flags&0x2000 != 0,
+		CustomFormat: flags&0x4000 != 0,
+	}, nil
+}
+
+// binaryCell is a cell read from a binary record, along with its zero based column index.
+type binaryCell struct {
+	rawCell
+	column int
+}
+
+// parseBinaryCell parses the record of a cell into the same form as a cell read from XML, so
+// that its value is interpreted in the same way. It reports false for records which are not
+// cells.
+func parseBinaryCell(typ int, data []byte) (binaryCell, bool, error) {
+	switch typ {
+	case brtCellBlank, brtCellRk, brtCellError, brtCellBool, brtCellReal, brtCellSt, brtCellIsst,
+		brtFmlaString, brtFmlaNum, brtFmlaBool, brtFmlaError, brtCellRString:
+	default:
+		return binaryCell{}, false, nil
+	}
+
+	// Each cell starts with its column, and the index of its style within the lower 24 bits
+	if len(data) < 8 {
+		return binaryCell{}, false, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+	}
+	le := binary.LittleEndian
+	cell := binaryCell{column: int(le.Uint32(data))}
+	cell.Style = int(le.Uint32(data[4:]) & 0x00FFFFFF)
+	data = data[8:]
+
+	var value string
+	switch typ {
+	case brtCellBlank:
+		return cell, true, nil
+	case brtCellRk:
+		if len(data) < 4 {
+			return binaryCell{}, false, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+		}
+		cell.Type = "n"
+		value = formatNumber(decodeRK(le.Uint32(data)))
+	case brtCellReal, brtFmlaNum:
+		if len(data) < 8 {
+			return binaryCell{}, false, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+		}
+		cell.Type = "n"
+		value = formatNumber(math.Float64frombits(le.Uint64(data)))
+	case brtCellBool, brtFmlaBool:
+		if len(data) < 1 {
+			return binaryCell{}, false, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+		}
+		cell.Type = "b"
+		value = "0"
+		if data[0] != 0 {
+			value = "1"
+		}
+	case brtCellError, brtFmlaError:
+		if len(data) < 1 {
+			return binaryCell{}, false, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+		}
+		cell.Type = "e"
+		value = string(getErrorFromCode(data[0]))
+	case brtCellIsst:
+		if len(data) < 4 {
+			return binaryCell{}, false, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+		}
+		cell.Type = "s"
+		value = strconv.FormatUint(uint64(le.Uint32(data)), 10)
+	case brtCellSt, brtFmlaString, brtCellRString:
+		if typ == brtCellRString {
+			// Rich strings have a byte of flags before the string
+			if len(data) < 1 {
+				return binaryCell{}, false, fmt.Errorf("unable to read cell: %w", errTruncatedRecord)
+			}
+			data = data[1:]
+		}
+		s, _, err := readWideString(data)
+		if err != nil {
+			return binaryCell{}, false, fmt.Errorf("unable to read cell: %w", err)
+		}
+		cell.Type = "str"
+		value = s
+	}
+
+	cell.Value = &value
+	return cell, true, nil
+}
+
+// decodeRK decodes a number stored in the compressed RK form, where the number is either an
+// integer, or the upper 32 bits of a float, and may have been multiplied by 100.
+func decodeRK(rk uint32) float64 {
+	var value float64
+	if rk&0x02 != 0 {
+		value = float64(int32(rk) >> 2)
+	} else {
+		value = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		value /= 100
+	}
+	return value
+}
+
+// formatNumber formats a number in the same way as it would be written to XML, without an
+// exponent unless the number is very large or very small.
+func formatNumber(value float64) string {
+	if abs := math.Abs(value); abs != 0 && (abs < 1e-9 || abs >= 1e21) {
+		return strconv.FormatFloat(value, 'E', -1, 64)
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// getErrorFromCode gives the error of a cell from the code used by the binary formats.
+func getErrorFromCode(code byte) CellError {
+	switch code {
+	case 0x00:
+		return CellErrorNull
+	case 0x07:
+		return CellErrorDiv0
+	case 0x0F:
+		return CellErrorValue
+	case 0x17:
+		return CellErrorRef
+	case 0x1D:
+		return CellErrorName
+	case 0x24:
+		return CellErrorNum
+	case 0x2A:
+		return CellErrorNA
+	case 0x2B:
+		return CellErrorGettingData
+	default:
+		return CellErrorUnknown
+	}
+}
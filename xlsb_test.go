@@ -0,0 +1,71 @@
+package xlsxreader
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var rkTests = []struct {
+	RK       uint32
+	Expected float64
+}{
+	{RK: 42<<2 | 2, Expected: 42},
+	{RK: 123<<2 | 3, Expected: 1.23},
+	{RK: 0x3FF00000, Expected: 1},
+	{RK: 0x3FF00001, Expected: 0.01},
+	{RK: 0xFFFFFFFE, Expected: -1},
+}
+
+func TestDecodingRK(t *testing.T) {
+	for _, test := range rkTests {
+		require.Equal(t, test.Expected, decodeRK(test.RK))
+	}
+}
+
+func TestReadingOversizedBinaryRecord(t *testing.T) {
+	// A record declaring a size of 0x0FFFFFFF bytes, within a part of only a few bytes
+	records := newBIFF12Reader(bytes.NewReader([]byte{0x94, 0x01, 0xFF, 0xFF, 0xFF, 0x7F, 0x00}), 7)
+
+	_, _, err := records.read()
+	require.Error(t, err)
+}
+
+var biff12RecordTests = []struct {
+	Name   string
+	Record []byte // The type and size of the record
+	Type   int
+	Size   int
+}{
+	{Name: "One byte", Record: []byte{0x01, 0x08}, Type: brtCellBlank, Size: 8},
+	{Name: "Two bytes", Record: []byte{0x94, 0x01, 0xC8, 0x01}, Type: brtWsDim, Size: 200},
+	{Name: "Three bytes", Record: []byte{0xFC, 0x08, 0xA0, 0x9C, 0x01}, Type: 1148, Size: 20000},
+	{Name: "Four bytes", Record: []byte{0x94, 0x01, 0xA0, 0x96, 0x80, 0x01}, Type: brtWsDim, Size: 2100000},
+}
+
+func TestReadingBinaryRecordLengths(t *testing.T) {
+	for _, test := range biff12RecordTests {
+		t.Run(test.Name, func(t *testing.T) {
+			// The record is followed by another, which must be read from where the first ends
+			part := append([]byte{}, test.Record...)
+			part = append(part, bytes.Repeat([]byte{0xAB}, test.Size)...)
+			part = append(part, 0x92, 0x01, 0x00)
+
+			records := newBIFF12Reader(bytes.NewReader(part), int64(len(part)))
+			typ, data, err := records.read()
+			require.NoError(t, err)
+			require.Equal(t, test.Type, typ)
+			require.Len(t, data, test.Size)
+
+			typ, data, err = records.read()
+			require.NoError(t, err)
+			require.Equal(t, brtEndSheetData, typ)
+			require.Empty(t, data)
+
+			_, _, err = records.read()
+			require.Equal(t, io.EOF, err)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package xlsxreader
 
 import (
+	"archive/zip"
 	"encoding/xml"
 	"fmt"
 )
@@ -19,3 +20,21 @@ func getCharData(d *xml.Decoder) (string, error) {
 
 	return string(cdata), nil
 }
+
+// unmarshalFile reads the named file from within an archive, and unmarshals its XML into v.
+func unmarshalFile(files []*zip.File, name string, v interface{}) error {
+	file, err := getFileForName(files, name)
+	if err != nil {
+		return err
+	}
+
+	data, err := readFile(file)
+	if err != nil {
+		return fmt.Errorf("unable to read file %s: %w", name, err)
+	}
+
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("unable to parse file %s: %w", name, err)
+	}
+	return nil
+}